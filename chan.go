@@ -0,0 +1,94 @@
+package seq
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type chanSeq struct {
+	mu       sync.Mutex
+	ch       reflect.Value
+	val      interface{}
+	realized bool
+	next     Sequence
+}
+
+func chanSeqNew(ch reflect.Value) Sequence {
+	v, ok := ch.Recv()
+	if !ok {
+		return nil
+	}
+	return &chanSeq{ch: ch, val: v.Interface()}
+}
+
+func (s *chanSeq) First() interface{} {
+	return s.val
+}
+
+func (s *chanSeq) Next() Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.realized {
+		s.next = chanSeqNew(s.ch)
+		s.realized = true
+	}
+	return s.next
+}
+
+func (s *chanSeq) String() string {
+	return seqString(s)
+}
+
+func (s *chanSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
+// FromChannel returns a lazy sequence of the values received from ch.
+// ch must be a channel that can be received from (chan T or <-chan T).
+// Each value is received as the sequence is realized and the sequence
+// ends when the channel is closed. Received values are cached so the
+// sequence may be traversed more than once even though the channel
+// can only be drained once.
+func FromChannel(ch interface{}) Sequence {
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan || v.Type().ChanDir()&reflect.RecvDir == 0 {
+		panic(fmt.Errorf("cannot receive from %T", ch))
+	}
+	return LazySeq(func() Sequence {
+		return chanSeqNew(v)
+	})
+}
+
+// ToChannel returns a channel with a buffer of size buf that receives
+// each element of coll in order. The elements are realized by a
+// goroutine which closes the channel once coll is exhausted. If coll is
+// infinite or the channel is not drained the goroutine will never exit;
+// use ToChannelContext to bound its lifetime. coll is any type that can
+// be converted to a Sequence by Seq.
+func ToChannel(coll interface{}, buf int) <-chan interface{} {
+	return ToChannelContext(context.Background(), coll, buf)
+}
+
+// ToChannelContext is like ToChannel but stops sending and closes the
+// channel once ctx is done. coll is any type that can be converted to a
+// Sequence by Seq.
+func ToChannelContext(
+	ctx context.Context,
+	coll interface{},
+	buf int,
+) <-chan interface{} {
+	ch := make(chan interface{}, buf)
+	go func() {
+		defer close(ch)
+		for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+			select {
+			case ch <- First(s):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,125 @@
+package seq
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFromChannel(t *testing.T) {
+	expected := []int{1, 2, 3, 4, 5}
+	ch := make(chan int, len(expected))
+	for _, v := range expected {
+		ch <- v
+	}
+	close(ch)
+	seq := FromChannel(ch)
+	for i := 0; i < 2; i++ {
+		got := Slice(seq)
+		if len(got) != len(expected) {
+			t.Fatal("wanted", expected, "got", got)
+		}
+		for idx, v := range expected {
+			if got[idx] != v {
+				t.Fatal("wanted", v, "got", got[idx], "at", idx)
+			}
+		}
+	}
+}
+
+func TestFromChannelRecvOnly(t *testing.T) {
+	ch := make(chan string, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+	var recv <-chan string = ch
+	if got := fmt.Sprint(FromChannel(recv)); got != "(a b)" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestFromChannelInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	FromChannel(make(chan<- int))
+}
+
+func ExampleFromChannel() {
+	ch := make(chan int)
+	go func() {
+		for i := 0; i < 5; i++ {
+			ch <- i * i
+		}
+		close(ch)
+	}()
+	fmt.Println(FromChannel(ch))
+	// Output: (0 1 4 9 16)
+}
+
+func TestToChannel(t *testing.T) {
+	var got []interface{}
+	for v := range ToChannel(RangeUntil(10), 2) {
+		got = append(got, v)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(Slice(RangeUntil(10))) {
+		t.Fatal("unexpected value", got)
+	}
+	if _, ok := <-ToChannel(nil, 0); ok {
+		t.Fatal("expected closed channel")
+	}
+}
+
+func TestToChannelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := ToChannelContext(ctx, RepeateInfinitely("x"), 0)
+	for i := 0; i < 5; i++ {
+		if v := <-ch; v != "x" {
+			t.Fatal("unexpected value", v)
+		}
+	}
+	cancel()
+	for range ch {
+	}
+}
+
+func ExampleToChannel() {
+	for v := range ToChannel(RangeUntil(3), 0) {
+		fmt.Println(v)
+	}
+	// Output:
+	// 0
+	// 1
+	// 2
+}
+
+func TestSeqChannel(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	s := Seq(ch)
+	first := Into([]int{}, s)
+	second := Into([]int{}, s)
+	if !reflect.DeepEqual(first, []int{1, 2, 3}) ||
+		!reflect.DeepEqual(second, first) {
+		t.Fatal("unexpected traversals", first, second)
+	}
+	if Seq(ch) != nil {
+		t.Fatal("expected drained channel to be empty")
+	}
+	var recvOnly <-chan int = ch
+	if Seq(recvOnly) != nil {
+		t.Fatal("expected drained channel to be empty")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for send-only channel")
+		}
+	}()
+	Seq(make(chan<- int))
+}
@@ -0,0 +1,118 @@
+package seq
+
+import (
+	"reflect"
+
+	"jsouthworth.net/go/transduce"
+)
+
+// chunkSize is the number of elements exposed per chunk by the chunked
+// sequences.
+const chunkSize = 32
+
+// chunk is a block of already available elements of a chunkedSeq.
+type chunk interface {
+	count() int
+	// reduce steps fn over the elements of the chunk, it stops early
+	// and returns the reduced value if fn returns a reduced value.
+	reduce(fn func(res, in interface{}) interface{}, init interface{}) interface{}
+}
+
+// chunkedSeq is implemented by sequences that can expose their
+// elements a block at a time. chunkFirst returns the block of elements
+// at the head of the sequence and chunkNext returns the sequence
+// following that block. This lets consumers such as Reduce and DoRun
+// walk the sequence without allocating a new sequence per element.
+type chunkedSeq interface {
+	Sequence
+	chunkFirst() chunk
+	chunkNext() Sequence
+}
+
+type sliceChunk struct {
+	v reflect.Value
+}
+
+func (c sliceChunk) count() int {
+	return c.v.Len()
+}
+
+func (c sliceChunk) reduce(
+	fn func(res, in interface{}) interface{},
+	init interface{},
+) interface{} {
+	res := init
+	for i := 0; i < c.v.Len(); i++ {
+		res = fn(res, c.v.Index(i).Interface())
+		if transduce.IsReduced(res) {
+			return res
+		}
+	}
+	return res
+}
+
+func (s sliceSeq) chunkLen() int {
+	if n := s.v.Len(); n < chunkSize {
+		return n
+	}
+	return chunkSize
+}
+
+func (s sliceSeq) chunkFirst() chunk {
+	return sliceChunk{v: s.v.Slice(0, s.chunkLen())}
+}
+
+func (s sliceSeq) chunkNext() Sequence {
+	return sliceSequenceAt(s.v.Slice(s.chunkLen(), s.v.Len()), s.i+s.chunkLen())
+}
+
+type rangeChunk struct {
+	start, step, n int
+}
+
+func (c rangeChunk) count() int {
+	return c.n
+}
+
+func (c rangeChunk) reduce(
+	fn func(res, in interface{}) interface{},
+	init interface{},
+) interface{} {
+	res := init
+	for i := 0; i < c.n; i++ {
+		res = fn(res, c.start+i*c.step)
+		if transduce.IsReduced(res) {
+			return res
+		}
+	}
+	return res
+}
+
+// chunkLen returns the number of elements, up to chunkSize, remaining
+// in the range.
+func (s *rangeSeq) chunkLen() int {
+	n := 1
+	for v := s.start; n < chunkSize; n++ {
+		next := v + s.step
+		if (s.step > 0 && (next < v || next >= s.end)) ||
+			(s.step < 0 && (next > v || next <= s.end)) {
+			break
+		}
+		v = next
+	}
+	return n
+}
+
+func (s *rangeSeq) chunkFirst() chunk {
+	return rangeChunk{start: s.start, step: s.step, n: s.chunkLen()}
+}
+
+func (s *rangeSeq) chunkNext() Sequence {
+	last := &rangeSeq{
+		start: s.start + (s.chunkLen()-1)*s.step,
+		end:   s.end,
+		step:  s.step,
+		i:     s.i + s.chunkLen() - 1,
+	}
+	return last.Next()
+}
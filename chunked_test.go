@@ -0,0 +1,106 @@
+package seq
+
+import (
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// walkSeq steps fn over the sequence an element at a time, ignoring
+// any chunks it exposes.
+func walkSeq(fn func(res, in interface{}) interface{}, init interface{}, s Sequence) interface{} {
+	ret := init
+	for ; s != nil; s = Next(s) {
+		ret = fn(ret, First(s))
+	}
+	return ret
+}
+
+func TestChunkedReduce(t *testing.T) {
+	conj := func(res, in interface{}) interface{} {
+		return append(res.([]interface{}), in)
+	}
+	if err := quick.Check(func(start, end int16, step int8) bool {
+		if step == 0 {
+			return true
+		}
+		s := Range(int(start), int(end), int(step))
+		return reflect.DeepEqual(
+			reduceSeq(conj, []interface{}{}, s),
+			walkSeq(conj, []interface{}{}, s))
+	}, nil); err != nil {
+		t.Error(err)
+	}
+	if err := quick.Check(func(is []int) bool {
+		s := sliceSequence(reflect.ValueOf(is))
+		return reflect.DeepEqual(
+			reduceSeq(conj, []interface{}{}, s),
+			walkSeq(conj, []interface{}{}, s))
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestChunkedRangeOverflow(t *testing.T) {
+	const maxInt = int(^uint(0) >> 1)
+	got := Reduce(func(res, in int) int {
+		return res + 1
+	}, 0, Range(maxInt-40, maxInt, 7))
+	if got != 6 {
+		t.Fatal("unexpected count", got)
+	}
+}
+
+func TestChunkedReduceReduced(t *testing.T) {
+	got := Reduce(func(res, in int) interface{} {
+		if in == 40 {
+			return Reduced(res)
+		}
+		return res + in
+	}, 0, RangeUntil(100))
+	if got != 780 {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestChunkedDoRun(t *testing.T) {
+	count := 0
+	DoRun(Map(func(in int) int {
+		count++
+		return in
+	}, Range(0, 100, 3)))
+	if count != 34 {
+		t.Fatal("unexpected count", count)
+	}
+}
+
+func BenchmarkChunked(b *testing.B) {
+	sum := func(a, b interface{}) interface{} {
+		return a.(int) + b.(int)
+	}
+	s := make([]int, 1000)
+	b.Run("slice-per-element", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			walkSeq(sum, 0, Seq(s))
+		}
+	})
+	b.Run("slice-chunked", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reduceSeq(sum, 0, Seq(s))
+		}
+	})
+	b.Run("range-per-element", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			walkSeq(sum, 0, RangeUntil(1000))
+		}
+	})
+	b.Run("range-chunked", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reduceSeq(sum, 0, RangeUntil(1000))
+		}
+	})
+}
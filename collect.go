@@ -0,0 +1,139 @@
+package seq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Collector describes a terminal operation that gathers the elements of
+// a sequence into a result. Supply returns a new accumulator,
+// Accumulate adds an element to an accumulator returning the updated
+// accumulator, and Finish converts the final accumulator to the result.
+type Collector interface {
+	Supply() interface{}
+	Accumulate(acc, elem interface{}) interface{}
+	Finish(acc interface{}) interface{}
+}
+
+// Collect gathers the elements of the sequence using collector and
+// returns the result. coll is any type that can be converted to a
+// Sequence by Seq.
+func Collect(collector Collector, coll interface{}) interface{} {
+	acc := Reduce(func(acc, elem interface{}) interface{} {
+		return collector.Accumulate(acc, elem)
+	}, collector.Supply(), coll)
+	return collector.Finish(acc)
+}
+
+type sliceCollector struct{}
+
+// ToSliceCollector returns a Collector that gathers the elements into a
+// []interface{}.
+func ToSliceCollector() Collector {
+	return sliceCollector{}
+}
+
+func (sliceCollector) Supply() interface{} {
+	return []interface{}{}
+}
+
+func (sliceCollector) Accumulate(acc, elem interface{}) interface{} {
+	return append(acc.([]interface{}), elem)
+}
+
+func (sliceCollector) Finish(acc interface{}) interface{} {
+	return acc
+}
+
+type mapCollector struct {
+	keyFn, valFn func(interface{}) interface{}
+}
+
+// ToMapCollector returns a Collector that gathers the elements into a
+// map[interface{}]interface{} using keyFn and valFn to compute the key
+// and value for each element. Later elements overwrite earlier ones
+// with the same key. keyFn and valFn must match the signature
+// func(i iT) oT and will be called using reflection unless they are the
+// non-specialized type func(interface{}) interface{}.
+func ToMapCollector(keyFn, valFn interface{}) Collector {
+	return mapCollector{
+		keyFn: wrapMapper(keyFn),
+		valFn: wrapMapper(valFn),
+	}
+}
+
+func (c mapCollector) Supply() interface{} {
+	return map[interface{}]interface{}{}
+}
+
+func (c mapCollector) Accumulate(acc, elem interface{}) interface{} {
+	acc.(map[interface{}]interface{})[c.keyFn(elem)] = c.valFn(elem)
+	return acc
+}
+
+func (c mapCollector) Finish(acc interface{}) interface{} {
+	return acc
+}
+
+type joiningCollector struct {
+	sep string
+}
+
+// JoiningCollector returns a Collector that formats each element with
+// fmt.Sprint and joins them into a string separated by sep.
+func JoiningCollector(sep string) Collector {
+	return joiningCollector{sep: sep}
+}
+
+func (c joiningCollector) Supply() interface{} {
+	return []string{}
+}
+
+func (c joiningCollector) Accumulate(acc, elem interface{}) interface{} {
+	return append(acc.([]string), fmt.Sprint(elem))
+}
+
+func (c joiningCollector) Finish(acc interface{}) interface{} {
+	return strings.Join(acc.([]string), c.sep)
+}
+
+type groupingCollector struct {
+	keyFn      func(interface{}) interface{}
+	downstream Collector
+}
+
+// GroupingCollector returns a Collector that groups the elements by the
+// result of keyFn and gathers each group with downstream. The result is
+// a map[interface{}]interface{} from each key to the result of its
+// group. keyFn must match the signature func(i iT) oT and will be
+// called using reflection unless it is the non-specialized type
+// func(interface{}) interface{}.
+func GroupingCollector(keyFn interface{}, downstream Collector) Collector {
+	return groupingCollector{
+		keyFn:      wrapMapper(keyFn),
+		downstream: downstream,
+	}
+}
+
+func (c groupingCollector) Supply() interface{} {
+	return map[interface{}]interface{}{}
+}
+
+func (c groupingCollector) Accumulate(acc, elem interface{}) interface{} {
+	groups := acc.(map[interface{}]interface{})
+	key := c.keyFn(elem)
+	group, ok := groups[key]
+	if !ok {
+		group = c.downstream.Supply()
+	}
+	groups[key] = c.downstream.Accumulate(group, elem)
+	return groups
+}
+
+func (c groupingCollector) Finish(acc interface{}) interface{} {
+	groups := acc.(map[interface{}]interface{})
+	for key, group := range groups {
+		groups[key] = c.downstream.Finish(group)
+	}
+	return groups
+}
@@ -0,0 +1,68 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestToSliceCollector(t *testing.T) {
+	got := Collect(ToSliceCollector(), RangeUntil(5))
+	exp := []interface{}{0, 1, 2, 3, 4}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	got = Collect(ToSliceCollector(), nil)
+	if !reflect.DeepEqual(got, []interface{}{}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestToMapCollector(t *testing.T) {
+	got := Collect(ToMapCollector(
+		func(s string) int { return len(s) },
+		func(s string) string { return s },
+	), []string{"a", "bb", "c", "ddd"})
+	exp := map[interface{}]interface{}{1: "c", 2: "bb", 3: "ddd"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func TestJoiningCollector(t *testing.T) {
+	if got := Collect(JoiningCollector(", "), RangeUntil(4)); got != "0, 1, 2, 3" {
+		t.Fatal("unexpected value", got)
+	}
+	if got := Collect(JoiningCollector(", "), nil); got != "" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestGroupingCollector(t *testing.T) {
+	parity := func(x int) string {
+		if x%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}
+	got := Collect(GroupingCollector(parity, JoiningCollector("")),
+		RangeUntil(10))
+	exp := map[interface{}]interface{}{"even": "02468", "odd": "13579"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	got = Collect(GroupingCollector(parity, ToSliceCollector()),
+		RangeUntil(4))
+	exp = map[interface{}]interface{}{
+		"even": []interface{}{0, 2},
+		"odd":  []interface{}{1, 3},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func ExampleCollect() {
+	fmt.Println(Collect(JoiningCollector("-"), Seq([]string{"a", "b", "c"})))
+	// Output: a-b-c
+}
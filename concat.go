@@ -0,0 +1,46 @@
+package seq
+
+// concatSeq chains a sequence with the collections that follow it. head
+// is never nil, exhausted collections are skipped when stepping.
+type concatSeq struct {
+	head Sequence
+	rest Sequence
+}
+
+// catSeqs returns a lazy sequence that is the concatenation of the
+// sequences contained in colls. colls itself may be lazy or infinite.
+func catSeqs(colls interface{}) Sequence {
+	return LazySeq(func() Sequence {
+		return concatNew(colls)
+	})
+}
+
+// concatNew returns the concatenation of colls starting at the first
+// collection that is not empty.
+func concatNew(colls interface{}) Sequence {
+	for s := Seq(colls); s != nil; s = Seq(s.Next()) {
+		if head := Seq(s.First()); head != nil {
+			return &concatSeq{head: head, rest: s.Next()}
+		}
+	}
+	return nil
+}
+
+func (s *concatSeq) First() interface{} {
+	return s.head.First()
+}
+
+func (s *concatSeq) Next() Sequence {
+	if next := Seq(s.head.Next()); next != nil {
+		return &concatSeq{head: next, rest: s.rest}
+	}
+	return concatNew(s.rest)
+}
+
+func (s *concatSeq) String() string {
+	return seqString(s)
+}
+
+func (s *concatSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
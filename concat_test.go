@@ -0,0 +1,70 @@
+package seq
+
+import (
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"jsouthworth.net/go/transduce"
+)
+
+// xfrmConcat is the transducer based concatenation Concat used to be.
+func xfrmConcat(colls ...interface{}) Sequence {
+	return XfrmSequence(transduce.Cat(Reduce), Seq(colls))
+}
+
+func TestConcatMatchesTransducer(t *testing.T) {
+	if err := quick.Check(func(a, b, c []int) bool {
+		got := Into([]int{}, Concat(a, nil, b, Seq(c)))
+		exp := Into([]int{}, xfrmConcat(a, nil, b, Seq(c)))
+		return reflect.DeepEqual(got, exp)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConcatIsLazy(t *testing.T) {
+	realized := false
+	s := Concat([]int{1}, LazySeq(func() Sequence {
+		realized = true
+		return Seq([]int{2})
+	}))
+	if First(s) != 1 || realized {
+		t.Fatal("realized a later sequence too early")
+	}
+	if got := Into([]int{}, s); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatal("unexpected value", got)
+	}
+	if Seq(Concat()) != nil || Seq(Concat(nil, []int{})) != nil {
+		t.Fatal("expected empty sequence")
+	}
+	if got := Into([]int{}, Take(4, Concat([]int{0}, RepeateInfinitely(1)))); !reflect.DeepEqual(got, []int{0, 1, 1, 1}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func BenchmarkConcat(b *testing.B) {
+	x, y := make([]int, 500), make([]int, 500)
+	b.Run("transducer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			DoRun(xfrmConcat(x, y))
+		}
+	})
+	b.Run("concat-seq", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			DoRun(Concat(x, y))
+		}
+	})
+}
+
+func TestCatSeqsInfiniteOuter(t *testing.T) {
+	s := catSeqs(Map(func(i int) interface{} {
+		return Take(i%3, RepeateInfinitely(i))
+	}, Iterate(func(i int) int { return i + 1 }, 0)))
+	got := Into([]int{}, Take(5, s))
+	if !reflect.DeepEqual(got, []int{1, 2, 2, 4, 5}) {
+		t.Fatal("unexpected value", got)
+	}
+}
@@ -3,6 +3,9 @@ package seq
 type cons struct {
 	first interface{}
 	next  Sequence
+	// count is the length of the sequence starting at this cell, or 0
+	// if the tail is not a counted cons and the length is unknown.
+	count int
 }
 
 func (s *cons) First() interface{} {
@@ -16,10 +19,31 @@ func (s *cons) String() string {
 	return seqString(s)
 }
 
+func (s *cons) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
 func consNew(first interface{}, next Sequence) *cons {
-	return &cons{first: first, next: next}
+	return &cons{first: first, next: next, count: consCount(next)}
+}
+
+// consCount returns the length of the list that results from consing
+// onto next, or 0 if it can't be known without traversing next.
+func consCount(next Sequence) int {
+	switch next := next.(type) {
+	case nil:
+		return 1
+	case *cons:
+		if next.count > 0 {
+			return next.count + 1
+		}
+	}
+	return 0
 }
 
+// Cons returns a sequence with v as its first element and coll as the
+// rest. When coll is empty or was itself built by Cons from a counted
+// list the length is cached so that Count is O(1).
 func Cons(v interface{}, coll Sequence) Sequence {
 	return consNew(v, coll)
 }
@@ -27,3 +51,17 @@ func Cons(v interface{}, coll Sequence) Sequence {
 func Empty() Sequence {
 	return nil
 }
+
+// List returns a sequence of the passed in items in order.
+func List(items ...interface{}) Sequence {
+	return ConsAll(nil, items...)
+}
+
+// ConsAll returns coll with the passed in items prepended in order, so
+// the first item is the first element of the result.
+func ConsAll(coll Sequence, items ...interface{}) Sequence {
+	for i := len(items) - 1; i >= 0; i-- {
+		coll = Cons(items[i], coll)
+	}
+	return coll
+}
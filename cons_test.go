@@ -0,0 +1,37 @@
+package seq
+
+import "testing"
+
+func TestConsCount(t *testing.T) {
+	var s Sequence
+	for i := 0; i < 100; i++ {
+		if got := Count(s); got != i {
+			t.Fatalf("expected %d, got %d", i, got)
+		}
+		s = Cons(i, s)
+	}
+	if c := s.(*cons); c.count != 100 {
+		t.Fatal("expected cached count 100, got", c.count)
+	}
+	if got := Count(List(1, 2, 3)); got != 3 {
+		t.Fatal("expected 3, got", got)
+	}
+}
+
+func TestConsCountUncountedTail(t *testing.T) {
+	tail := Seq([]int{1, 2, 3})
+	s := Cons(-1, Cons(0, tail))
+	if c := s.(*cons); c.count != 0 {
+		t.Fatal("expected unknown count, got", c.count)
+	}
+	if got := Count(s); got != 5 {
+		t.Fatal("expected 5, got", got)
+	}
+	lazy := Cons(0, LazySeq(func() Sequence { return List(1, 2) }))
+	if got := Count(lazy); got != 3 {
+		t.Fatal("expected 3, got", got)
+	}
+	if got := Count(Cons(0, Cons(1, Seq(Next(lazy))))); got != 4 {
+		t.Fatal("expected 4, got", got)
+	}
+}
@@ -0,0 +1,55 @@
+package seq
+
+// indexedSeq is implemented by sequences that know their position in
+// the collection they were created from.
+type indexedSeq interface {
+	index() int
+}
+
+func (s sliceSeq) index() int {
+	return s.i
+}
+
+func (s *rangeSeq) index() int {
+	return s.i
+}
+
+func (s *rangeFloatSeq) index() int {
+	return s.i
+}
+
+// Cursor splits coll into its first element and the rest of the
+// sequence. ok is false if coll is empty. coll is any type that can be
+// converted to a Sequence by Seq.
+func Cursor(coll interface{}) (first interface{}, rest Sequence, ok bool) {
+	s := Seq(coll)
+	if s == nil {
+		return nil, nil, false
+	}
+	return First(s), Next(s), true
+}
+
+// SaveCursor returns the position of coll within the collection it was
+// created from, such as the rest returned by Cursor. The position can
+// be passed to RestoreCursor along with the original collection to
+// resume iteration later. Only sequences of slices, strings and ranges
+// know their position, ok is false for any other sequence and for an
+// empty coll. coll is any type that can be converted to a Sequence by
+// Seq.
+func SaveCursor(coll interface{}) (int, bool) {
+	s, ok := Seq(coll).(indexedSeq)
+	if !ok {
+		return 0, false
+	}
+	return s.index(), true
+}
+
+// RestoreCursor returns the sequence of original starting at index, a
+// position returned by SaveCursor. original must be re-iterable and
+// produce the same elements every time it is traversed, such as a
+// slice or a range, for the resumed sequence to continue where the
+// saved one left off. original is any type that can be converted to a
+// Sequence by Seq.
+func RestoreCursor(original interface{}, index int) Sequence {
+	return nthNext(Seq(original), index)
+}
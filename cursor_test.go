@@ -0,0 +1,60 @@
+package seq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCursorRange(t *testing.T) {
+	r := Range(10, 100, 10)
+	first, rest, ok := Cursor(r)
+	if !ok || first != 10 {
+		t.Fatal("unexpected cursor", first, ok)
+	}
+	_, rest, _ = Cursor(rest)
+	pos, ok := SaveCursor(rest)
+	if !ok || pos != 2 {
+		t.Fatal("unexpected position", pos, ok)
+	}
+	got := Into([]int{}, RestoreCursor(Range(10, 100, 10), pos))
+	exp := []int{30, 40, 50, 60, 70, 80, 90}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func TestCursorSlice(t *testing.T) {
+	is := make([]int, 100)
+	for i := range is {
+		is[i] = i
+	}
+	// Skip far enough to cross a chunk boundary.
+	s := Seq(is)
+	for i := 0; i < 40; i++ {
+		_, s, _ = Cursor(s)
+	}
+	pos, ok := SaveCursor(s)
+	if !ok || pos != 40 {
+		t.Fatal("unexpected position", pos, ok)
+	}
+	if got := First(RestoreCursor(is, pos)); got != 40 {
+		t.Fatal("unexpected value", got)
+	}
+	if _, ok := SaveCursor(Map(func(x int) int { return x }, is)); ok {
+		t.Fatal("expected lazy sequence to not have a position")
+	}
+	if _, _, ok := Cursor(nil); ok {
+		t.Fatal("expected empty cursor")
+	}
+}
+
+func TestCursorChunked(t *testing.T) {
+	s := Range(0, 100, 1).(chunkedSeq).chunkNext()
+	if pos, _ := SaveCursor(s); pos != 32 || First(s) != 32 {
+		t.Fatal("unexpected position", pos, First(s))
+	}
+	s = Seq(make([]int, 50)).(chunkedSeq).chunkNext()
+	if pos, _ := SaveCursor(s); pos != 32 {
+		t.Fatal("unexpected position", pos)
+	}
+}
@@ -5,7 +5,10 @@ type cycle struct {
 	seq Sequence
 }
 
-func cycleSeq(all Sequence) *cycle {
+func cycleSeq(all Sequence) Sequence {
+	if all == nil {
+		return nil
+	}
 	return &cycle{all: all, seq: all}
 }
 
@@ -22,5 +25,9 @@ func (c *cycle) Next() Sequence {
 }
 
 func (c *cycle) String() string {
-	return seqString(c)
+	return infiniteSeqString(c)
+}
+
+func (c *cycle) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(c)
 }
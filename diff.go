@@ -0,0 +1,67 @@
+package seq
+
+import (
+	"reflect"
+)
+
+// Edit operations produced by Diff.
+const (
+	EditKeep   = "keep"
+	EditAdd    = "add"
+	EditRemove = "remove"
+)
+
+// Edit is a single operation in an edit script produced by Diff.
+// Op is one of EditKeep, EditAdd or EditRemove.
+type Edit struct {
+	Op    string
+	Value interface{}
+}
+
+// Diff returns a sequence of Edit values that transforms a into b.
+// The edit script is computed from the longest common subsequence of
+// a and b using reflect.DeepEqual to compare elements. Both a and b
+// are fully realized so they must be finite. a and b are any type that
+// can be converted to a Sequence by Seq.
+func Diff(a, b interface{}) Sequence {
+	as, bs := Slice(a), Slice(b)
+	lcs := make([][]int, len(as)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bs)+1)
+	}
+	for i := len(as) - 1; i >= 0; i-- {
+		for j := len(bs) - 1; j >= 0; j-- {
+			switch {
+			case reflect.DeepEqual(as[i], bs[j]):
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var edits []Edit
+	i, j := 0, 0
+	for i < len(as) && j < len(bs) {
+		switch {
+		case reflect.DeepEqual(as[i], bs[j]):
+			edits = append(edits, Edit{Op: EditKeep, Value: as[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, Edit{Op: EditRemove, Value: as[i]})
+			i++
+		default:
+			edits = append(edits, Edit{Op: EditAdd, Value: bs[j]})
+			j++
+		}
+	}
+	for ; i < len(as); i++ {
+		edits = append(edits, Edit{Op: EditRemove, Value: as[i]})
+	}
+	for ; j < len(bs); j++ {
+		edits = append(edits, Edit{Op: EditAdd, Value: bs[j]})
+	}
+	return Seq(edits)
+}
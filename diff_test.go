@@ -0,0 +1,43 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := []string{"one", "two", "three", "four"}
+	b := []string{"one", "three", "four", "five"}
+	expected := []Edit{
+		{Op: EditKeep, Value: "one"},
+		{Op: EditRemove, Value: "two"},
+		{Op: EditKeep, Value: "three"},
+		{Op: EditKeep, Value: "four"},
+		{Op: EditAdd, Value: "five"},
+	}
+	got := Slice(Diff(a, b))
+	if len(got) != len(expected) {
+		t.Fatal("wanted", expected, "got", got)
+	}
+	for i, e := range expected {
+		if !reflect.DeepEqual(got[i], e) {
+			t.Fatal("wanted", e, "got", got[i], "at", i)
+		}
+	}
+}
+
+func TestDiffEmpty(t *testing.T) {
+	if Diff(nil, []int{}) != nil {
+		t.Fatal("expected empty edit script")
+	}
+	got := Slice(Diff(nil, []int{1, 2}))
+	if len(got) != 2 || got[0] != (Edit{Op: EditAdd, Value: 1}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func ExampleDiff() {
+	fmt.Println(Diff([]string{"a", "b", "c"}, []string{"a", "c", "d"}))
+	// Output: ({keep a} {remove b} {keep c} {add d})
+}
@@ -0,0 +1,46 @@
+package seq
+
+import (
+	"jsouthworth.net/go/transduce"
+)
+
+type eduction struct {
+	xf   transduce.Transducer
+	coll interface{}
+}
+
+// Eduction returns a sequence that applies the transducer to coll anew
+// every time it is traversed or reduced. Unlike XfrmSequence, which
+// steps the transducer once and caches the results, an eduction keeps
+// no results and starts the transducer with fresh state each time, so
+// stateful transducers such as Take or Dedupe behave the same on every
+// pass. The transformation is recomputed on each traversal so coll
+// should be re-iterable. coll is any type that can be converted to a
+// Sequence by Seq.
+func Eduction(xf transduce.Transducer, coll interface{}) Sequence {
+	return &eduction{xf: xf, coll: coll}
+}
+
+func (e *eduction) Seq() Sequence {
+	return Seq(XfrmSequence(e.xf, Seq(e.coll)))
+}
+
+func (e *eduction) First() interface{} {
+	return First(e.Seq())
+}
+
+func (e *eduction) Next() Sequence {
+	return Next(e.Seq())
+}
+
+func (e *eduction) Reduce(fn, init interface{}) interface{} {
+	return Transduce(e.xf, fn, init, e.coll)
+}
+
+func (e *eduction) String() string {
+	return seqString(e)
+}
+
+func (e *eduction) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(e)
+}
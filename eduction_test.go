@@ -0,0 +1,37 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"jsouthworth.net/go/transduce"
+)
+
+func TestEduction(t *testing.T) {
+	steps := 0
+	e := Eduction(transduce.Compose(
+		transduce.Map(func(x int) int {
+			steps++
+			return x / 2
+		}),
+		transduce.Dedupe(),
+		transduce.Take(3),
+	), RangeUntil(100))
+	sum := func(a, b int) int { return a + b }
+	first, second := Reduce(sum, 0, e), Reduce(sum, 0, e)
+	if first != 3 || second != 3 {
+		t.Fatal("unexpected results", first, second)
+	}
+	if steps != 10 {
+		t.Fatal("expected the transducer to run on each reduction", steps)
+	}
+	got1, got2 := Slice(e), Slice(e)
+	if !reflect.DeepEqual(got1, got2) ||
+		!reflect.DeepEqual(got1, []interface{}{0, 1, 2}) {
+		t.Fatal("unexpected traversals", got1, got2)
+	}
+	if fmt.Sprint(e) != "(0 1 2)" {
+		t.Fatal("unexpected string", e)
+	}
+}
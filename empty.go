@@ -0,0 +1,29 @@
+package seq
+
+// emptySeq is a sequence with no elements. Unlike a nil Sequence it is
+// a value whose methods may be called. Seq of an emptySeq is nil so it
+// is treated as empty by the rest of the package.
+type emptySeq struct{}
+
+// empty is the canonical empty sequence.
+var empty Sequence = emptySeq{}
+
+func (emptySeq) First() interface{} {
+	return nil
+}
+
+func (emptySeq) Next() Sequence {
+	return nil
+}
+
+func (emptySeq) Seq() Sequence {
+	return nil
+}
+
+func (emptySeq) String() string {
+	return "()"
+}
+
+func (emptySeq) MarshalJSON() ([]byte, error) {
+	return []byte("[]"), nil
+}
@@ -0,0 +1,213 @@
+package seq
+
+import (
+	"reflect"
+
+	"jsouthworth.net/go/transduce"
+)
+
+// Equality reports whether two elements should be considered equal.
+type Equality func(a, b interface{}) bool
+
+// Option configures the behavior of the membership based operations
+// such as DistinctOpts and ContainsOpts.
+type Option func(*options)
+
+type options struct {
+	eq Equality
+}
+
+func buildOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// equal returns the configured Equality or reflect.DeepEqual if none
+// was provided.
+func (o *options) equal() Equality {
+	if o.eq == nil {
+		return reflect.DeepEqual
+	}
+	return o.eq
+}
+
+// WithEquality configures an operation to compare elements with eq
+// instead of the default comparison. Operations that would otherwise
+// track membership with a hash set must instead compare against every
+// element seen so far, so membership checks degrade to O(n) per element.
+func WithEquality(eq func(a, b interface{}) bool) Option {
+	return func(o *options) {
+		o.eq = eq
+	}
+}
+
+// seenSet tracks the elements seen by an operation. Without a custom
+// equality comparable elements are hashed and only non-comparable
+// elements are compared linearly with reflect.DeepEqual.
+// isHashable reports whether x can be used as a map key without
+// panicking. Unlike reflect.Type.Comparable it also checks the dynamic
+// values held in interface fields of structs and arrays.
+func isHashable(x interface{}) bool {
+	return x == nil || reflect.ValueOf(x).Comparable()
+}
+
+type seenSet struct {
+	eq     Equality
+	hashed map[interface{}]struct{}
+	others []interface{}
+}
+
+func newSeenSet(eq Equality) *seenSet {
+	return &seenSet{
+		eq:     eq,
+		hashed: make(map[interface{}]struct{}),
+	}
+}
+
+func (s *seenSet) hashable(x interface{}) bool {
+	return s.eq == nil && isHashable(x)
+}
+
+func (s *seenSet) contains(x interface{}) bool {
+	if s.hashable(x) {
+		_, ok := s.hashed[x]
+		return ok
+	}
+	eq := s.eq
+	if eq == nil {
+		eq = reflect.DeepEqual
+	}
+	for _, other := range s.others {
+		if eq(other, x) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *seenSet) add(x interface{}) {
+	if s.hashable(x) {
+		s.hashed[x] = struct{}{}
+		return
+	}
+	s.others = append(s.others, x)
+}
+
+func (s *seenSet) len() int {
+	return len(s.hashed) + len(s.others)
+}
+
+func distinct(eq Equality) transduce.Transducer {
+	return func(rf transduce.ReducerFn) transduce.ReducerFn {
+		seen := newSeenSet(eq)
+		return transduce.Reducing(
+			func(result, input interface{}) interface{} {
+				if seen.contains(input) {
+					return result
+				}
+				seen.add(input)
+				return rf.Step(result, input)
+			},
+		)(rf)
+	}
+}
+
+func distinctTrace() transduce.Transducer {
+	return func(rf transduce.ReducerFn) transduce.ReducerFn {
+		seen := newSeenSet(nil)
+		return transduce.Reducing(
+			func(result, input interface{}) interface{} {
+				if !seen.contains(input) {
+					seen.add(input)
+				}
+				return rf.Step(result, seen.len())
+			},
+		)(rf)
+	}
+}
+
+// dedupe removes consecutive elements considered equal by eq, calling
+// onDrop, if it is non-nil, with each element removed. As with
+// transduce.Dedupe, leading nil elements are removed too, without
+// calling eq.
+func dedupe(eq Equality, onDrop func(interface{})) transduce.Transducer {
+	return func(rf transduce.ReducerFn) transduce.ReducerFn {
+		var prior interface{}
+		started := false
+		return transduce.Reducing(
+			func(result, input interface{}) interface{} {
+				if (started && eq(prior, input)) ||
+					(!started && input == nil) {
+					if onDrop != nil {
+						onDrop(input)
+					}
+					return result
+				}
+				started = true
+				prior = input
+				return rf.Step(result, input)
+			},
+		)(rf)
+	}
+}
+
+// Distinct returns a lazy sequence of the elements of coll with
+// duplicates removed. Only the first occurrence of each element is
+// kept. coll is any type that can be converted to a Sequence by Seq.
+func Distinct(coll interface{}) Sequence {
+	return DistinctOpts(coll)
+}
+
+// DistinctOpts is Distinct configured by opts. coll is any type that can
+// be converted to a Sequence by Seq.
+func DistinctOpts(coll interface{}, opts ...Option) Sequence {
+	o := buildOptions(opts)
+	return XfrmSequence(distinct(o.eq), Seq(coll))
+}
+
+// DistinctTrace returns a lazy sequence of the number of distinct
+// elements of coll seen up to and including each position. Comparable
+// elements are tracked in a hash set while non-comparable elements
+// are compared with reflect.DeepEqual. coll is any type that can be
+// converted to a Sequence by Seq.
+func DistinctTrace(coll interface{}) Sequence {
+	return XfrmSequence(distinctTrace(), Seq(coll))
+}
+
+// DedupeOpts is Dedupe configured by opts. Leading nil elements are
+// removed whether or not an equality is given. coll is any type that
+// can be converted to a Sequence by Seq.
+func DedupeOpts(coll interface{}, opts ...Option) Sequence {
+	o := buildOptions(opts)
+	if o.eq == nil {
+		return Dedupe(coll)
+	}
+	return XfrmSequence(dedupe(o.eq, nil), Seq(coll))
+}
+
+// ContainsOpts reports whether any element of coll is equal to target
+// as configured by opts. By default elements are compared with
+// reflect.DeepEqual. It stops at the first match. coll is any type that
+// can be converted to a Sequence by Seq.
+func ContainsOpts(coll interface{}, target interface{}, opts ...Option) bool {
+	return IndexOfOpts(coll, target, opts...) >= 0
+}
+
+// IndexOfOpts returns the index of the first element of coll equal to
+// target as configured by opts or -1 if there is none. By default
+// elements are compared with reflect.DeepEqual. It stops at the first
+// match. coll is any type that can be converted to a Sequence by Seq.
+func IndexOfOpts(coll interface{}, target interface{}, opts ...Option) int {
+	eq := buildOptions(opts).equal()
+	idx := 0
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		if eq(First(s), target) {
+			return idx
+		}
+		idx++
+	}
+	return -1
+}
@@ -0,0 +1,91 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func caseInsensitive(a, b interface{}) bool {
+	return strings.EqualFold(a.(string), b.(string))
+}
+
+func TestDistinct(t *testing.T) {
+	got := fmt.Sprint(Distinct([]int{1, 2, 1, 3, 2, 4}))
+	if got != "(1 2 3 4)" {
+		t.Fatal("unexpected value", got)
+	}
+	got = fmt.Sprint(Distinct([][]int{{1}, {2}, {1}}))
+	if got != "([1] [2])" {
+		t.Fatal("unexpected value", got)
+	}
+	type key struct{ v interface{} }
+	got = fmt.Sprint(Distinct([]key{{[]int{1}}, {1}, {[]int{1}}}))
+	if got != "({[1]} {1})" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestDistinctTrace(t *testing.T) {
+	got := fmt.Sprint(DistinctTrace([]int{1, 2, 2, 3, 1}))
+	if got != "(1 2 2 3 3)" {
+		t.Fatal("unexpected value", got)
+	}
+	got = fmt.Sprint(DistinctTrace([][]int{{1}, {1}, {2}}))
+	if got != "(1 1 2)" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func ExampleDistinctTrace() {
+	fmt.Println(DistinctTrace([]string{"a", "b", "a", "c"}))
+	// Output: (1 2 2 3)
+}
+
+func TestDistinctOptsWithEquality(t *testing.T) {
+	words := []string{"Go", "go", "Seq", "GO", "seq", "lazy"}
+	got := fmt.Sprint(DistinctOpts(words, WithEquality(caseInsensitive)))
+	if got != "(Go Seq lazy)" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestDedupeOptsWithEquality(t *testing.T) {
+	words := []string{"Go", "go", "Seq", "GO", "go"}
+	got := fmt.Sprint(DedupeOpts(words, WithEquality(caseInsensitive)))
+	if got != "(Go Seq GO)" {
+		t.Fatal("unexpected value", got)
+	}
+	in := []interface{}{nil, 1, 1, nil}
+	exp := Slice(DedupeOpts(in))
+	withEq := Slice(DedupeOpts(in, WithEquality(reflect.DeepEqual)))
+	if !reflect.DeepEqual(exp, []interface{}{1, nil}) ||
+		!reflect.DeepEqual(withEq, exp) {
+		t.Fatal("leading nil handled differently", exp, withEq)
+	}
+}
+
+func TestContainsOptsWithEquality(t *testing.T) {
+	words := []string{"Go", "Seq", "lazy"}
+	if ContainsOpts(words, "seq") {
+		t.Fatal("default equality should be case sensitive")
+	}
+	if !ContainsOpts(words, "seq", WithEquality(caseInsensitive)) {
+		t.Fatal("expected to find seq")
+	}
+	if ContainsOpts(words, "eager", WithEquality(caseInsensitive)) {
+		t.Fatal("unexpectedly found eager")
+	}
+	if idx := IndexOfOpts(words, "LAZY", WithEquality(caseInsensitive)); idx != 2 {
+		t.Fatal("wanted", 2, "got", idx)
+	}
+}
+
+func ExampleDistinctOpts() {
+	fmt.Println(DistinctOpts([]string{"a", "A", "b", "B", "a"},
+		WithEquality(func(a, b interface{}) bool {
+			return strings.EqualFold(a.(string), b.(string))
+		})))
+	// Output: (a b)
+}
@@ -0,0 +1,48 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FlattenMap returns a lazy sequence of MapEntry values for the leaves of
+// the nested map m. The key of each entry is the path of keys leading
+// to the leaf joined by sep, e.g. "a.b.c". Any value that is not a map,
+// including slices, is a leaf. Keys are converted to strings with
+// fmt.Sprint. The resulting sequence may be placed into a flat map with
+// Into(map[string]interface{}{}, FlattenMap(".", m)).
+func FlattenMap(sep string, m interface{}) Sequence {
+	return flattenMapEntries(sep, "", true, Seq(m))
+}
+
+// flattenMapEntries flattens entries whose keys are joined onto prefix
+// unless root is true, in which case there is no prefix yet.
+func flattenMapEntries(
+	sep, prefix string,
+	root bool,
+	entries Sequence,
+) Sequence {
+	return LazySeq(func() Sequence {
+		if entries == nil {
+			return nil
+		}
+		ent := First(entries).(MapEntry)
+		key := fmt.Sprint(ent.Key())
+		if !root {
+			key = prefix + sep + key
+		}
+		rest := func() Sequence {
+			return flattenMapEntries(sep, prefix, root, Seq(Next(entries)))
+		}
+		val := ent.Value()
+		if val != nil && reflect.TypeOf(val).Kind() == reflect.Map {
+			return LazyCat(
+				func() Sequence {
+					return flattenMapEntries(sep, key, false, Seq(val))
+				},
+				rest,
+			)
+		}
+		return Cons(mapEntry{key: key, val: val}, LazySeq(rest))
+	})
+}
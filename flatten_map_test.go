@@ -0,0 +1,55 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenMap(t *testing.T) {
+	m := map[string]interface{}{
+		"name": "seq",
+		"db": map[string]interface{}{
+			"host": "localhost",
+			"port": 5432,
+			"opts": map[string]interface{}{
+				"ssl": true,
+			},
+		},
+		"tags":  []string{"a", "b"},
+		"empty": map[string]interface{}{},
+	}
+	expected := map[string]interface{}{
+		"name":        "seq",
+		"db.host":     "localhost",
+		"db.port":     5432,
+		"db.opts.ssl": true,
+		"tags":        []string{"a", "b"},
+	}
+	got := Into(map[string]interface{}{}, FlattenMap(".", m))
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatal("wanted", expected, "got", got)
+	}
+	m = map[string]interface{}{
+		"": map[string]interface{}{"a": 1, "": 2},
+	}
+	expected = map[string]interface{}{".a": 1, ".": 2}
+	got = Into(map[string]interface{}{}, FlattenMap(".", m))
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatal("wanted", expected, "got", got)
+	}
+}
+
+func ExampleFlattenMap() {
+	m := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{
+				"c": 1,
+			},
+		},
+	}
+	fmt.Println(Map(func(e MapEntry) string {
+		return fmt.Sprint(e.Key(), "=", e.Value())
+	}, FlattenMap("/", m)))
+	// Output: (a/b/c=1)
+}
@@ -0,0 +1,87 @@
+package seq
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Fold is a parallel Reduce. The finite sequence coll is partitioned
+// into chunks of n elements, each chunk is reduced with reducef in its
+// own goroutine starting from the value of calling combinef with no
+// arguments, and the partial results are then merged in order with
+// combinef. combinef called with no arguments must return the identity
+// value for the reduction, and called with two arguments must merge two
+// partial results. Since chunks are reduced independently Fold only
+// gives the same result as Reduce when the operations are associative.
+// If n is not positive a chunk size of 512 is used. At most GOMAXPROCS
+// chunks are reduced at once, and if reducef panics the panic is raised
+// in the caller once all chunks have finished. combinef must
+// match the signature func(a ...rT) rT or be callable with both zero
+// and two arguments, reducef must match the signature
+// func(result rT, input iT) rT. Both are called using reflection unless
+// they are the non-specialized types. coll is any type that can be
+// converted to a Sequence by Seq.
+func Fold(
+	n int,
+	combinef interface{},
+	reducef interface{},
+	coll interface{},
+) interface{} {
+	if n <= 0 {
+		n = 512
+	}
+	combine := wrapCombine(combinef)
+	chunks := Slice(PartitionAll(n, coll))
+	partials := make([]interface{}, len(chunks))
+	panics := make([]interface{}, len(chunks))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(chunks) {
+		workers = len(chunks)
+	}
+	work := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				partials[i], panics[i] = foldChunk(reducef, combine(), chunks[i])
+			}
+		}()
+	}
+	for i := range chunks {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	for _, p := range panics {
+		if p != nil {
+			panic(p)
+		}
+	}
+	return reduceSeq(func(res, in interface{}) interface{} {
+		return combine(res, in)
+	}, combine(), Seq(partials))
+}
+
+// foldChunk reduces a single chunk for Fold, returning any panic
+// raised by reducef instead of letting it escape its goroutine.
+func foldChunk(
+	reducef, init, chunk interface{},
+) (res interface{}, panicked interface{}) {
+	defer func() {
+		panicked = recover()
+	}()
+	return Reduce(reducef, init, chunk), nil
+}
+
+func wrapCombine(f interface{}) func(...interface{}) interface{} {
+	switch fn := f.(type) {
+	case func(...interface{}) interface{}:
+		return fn
+	default:
+		return func(args ...interface{}) interface{} {
+			return apply(f, args...)
+		}
+	}
+}
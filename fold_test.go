@@ -0,0 +1,55 @@
+package seq
+
+import (
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	add := func(xs ...int) int {
+		sum := 0
+		for _, x := range xs {
+			sum += x
+		}
+		return sum
+	}
+	got := Fold(100, add, func(res, x int) int {
+		return res + x
+	}, RangeUntil(10000))
+	if got != 49995000 {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestFoldEmpty(t *testing.T) {
+	got := Fold(10, func(xs ...interface{}) interface{} {
+		if len(xs) == 0 {
+			return 0
+		}
+		return xs[0].(int) + xs[1].(int)
+	}, func(res, x int) int {
+		return res + x
+	}, nil)
+	if got != 0 {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestFoldPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "bad element" {
+			t.Fatal("expected reducef panic, got", r)
+		}
+	}()
+	Fold(10, func(xs ...int) int {
+		if len(xs) == 0 {
+			return 0
+		}
+		return xs[0] + xs[1]
+	}, func(res, x int) int {
+		if x == 42 {
+			panic("bad element")
+		}
+		return res + x
+	}, RangeUntil(1000))
+	t.Fatal("expected panic")
+}
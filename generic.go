@@ -0,0 +1,37 @@
+package seq
+
+// MapG is a type safe version of Map. It returns a lazy sequence that
+// contains the result of applying fn to each item of coll. fn is called
+// directly without reflection. The items of coll must be of type T.
+func MapG[T, U any](fn func(T) U, coll Sequence) Sequence {
+	return Map(func(in interface{}) interface{} {
+		return fn(asType[T](in))
+	}, coll)
+}
+
+// FilterG is a type safe version of Filter. It returns a lazy sequence
+// that contains the items of coll for which pred is true. pred is called
+// directly without reflection. The items of coll must be of type T.
+func FilterG[T any](pred func(T) bool, coll Sequence) Sequence {
+	return Filter(func(in interface{}) bool {
+		return pred(asType[T](in))
+	}, coll)
+}
+
+// ReduceG is a type safe version of Reduce. It returns the result of
+// reducing the items of coll with fn starting with init. fn is called
+// directly without reflection. The items of coll must be of type T.
+func ReduceG[T, A any](fn func(A, T) A, init A, coll Sequence) A {
+	return asType[A](Reduce(func(res, in interface{}) interface{} {
+		return fn(asType[A](res), asType[T](in))
+	}, init, coll))
+}
+
+// asType asserts x is a T, treating nil as the zero value of T.
+func asType[T any](x interface{}) T {
+	if x == nil {
+		var zero T
+		return zero
+	}
+	return x.(T)
+}
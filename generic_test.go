@@ -0,0 +1,90 @@
+package seq
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/quick"
+)
+
+func TestMapG(t *testing.T) {
+	if err := quick.Check(func(is []int) bool {
+		got := Into([]string{}, MapG(strconv.Itoa, Seq(is)))
+		exp := Into([]string{}, Map(strconv.Itoa, Seq(is)))
+		return reflect.DeepEqual(got, exp)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFilterG(t *testing.T) {
+	even := func(x int) bool { return x%2 == 0 }
+	if err := quick.Check(func(is []int) bool {
+		got := Into([]int{}, FilterG(even, Seq(is)))
+		exp := Into([]int{}, Filter(even, Seq(is)))
+		return reflect.DeepEqual(got, exp)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReduceG(t *testing.T) {
+	sum := func(a float64, x int) float64 { return a + float64(x) }
+	if got := ReduceG(sum, 0.5, RangeUntil(5)); got != 10.5 {
+		t.Fatal("unexpected value", got)
+	}
+	if got := ReduceG(sum, 1, nil); got != 1 {
+		t.Fatal("unexpected value", got)
+	}
+	errs := ReduceG(func(a []error, x error) []error {
+		return append(a, x)
+	}, nil, Seq([]error{nil, nil}))
+	if len(errs) != 2 {
+		t.Fatal("unexpected value", errs)
+	}
+}
+
+func TestGenericComposition(t *testing.T) {
+	got := ReduceG(func(a, x int) int { return a + x }, 0,
+		MapG(func(x int) int { return x * x },
+			FilterG(func(x int) bool { return x%2 == 1 }, RangeUntil(6))))
+	if got != 35 {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func BenchmarkGeneric(b *testing.B) {
+	s := make([]int, 1000)
+	b.Run("map-reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			DoRun(Map(func(in int) int {
+				return in + 10
+			}, Seq(s)))
+		}
+	})
+	b.Run("map-generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			DoRun(MapG(func(in int) int {
+				return in + 10
+			}, Seq(s)))
+		}
+	})
+	b.Run("reduce-reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Reduce(func(a, x int) int {
+				return a + x
+			}, 0, Seq(s))
+		}
+	})
+	b.Run("reduce-generic", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ReduceG(func(a, x int) int {
+				return a + x
+			}, 0, Seq(s))
+		}
+	})
+}
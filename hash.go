@@ -0,0 +1,123 @@
+package seq
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Hash returns an order dependent FNV-1a hash of the elements of coll.
+// Elements are hashed by walking their values the same way
+// reflect.DeepEqual compares them, so pointers contribute what they
+// point to rather than their address, and nested sequences contribute
+// their own Hash. Sequences that are equal according to SequenceEqual
+// have the same hash. The whole sequence is realized so coll must be
+// finite. coll is any type that can be converted to a Sequence by Seq.
+func Hash(coll interface{}) uint64 {
+	h := fnv.New64a()
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		switch v := First(s).(type) {
+		case Sequence:
+			hashUint(h, 's', Hash(v))
+		default:
+			hashValue(h, reflect.ValueOf(v), map[uintptr]bool{})
+		}
+	}
+	return h.Sum64()
+}
+
+// hashUint writes a kind tag followed by x so that values of different
+// kinds and element boundaries affect the hash.
+func hashUint(h hash.Hash64, tag byte, x uint64) {
+	var buf [9]byte
+	buf[0] = tag
+	binary.LittleEndian.PutUint64(buf[1:], x)
+	h.Write(buf[:])
+}
+
+// hashValue writes v to h such that values that are equal according to
+// reflect.DeepEqual write the same bytes. seen holds the pointers being
+// walked so cyclic values terminate.
+func hashValue(h hash.Hash64, v reflect.Value, seen map[uintptr]bool) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		hashUint(h, 'n', 0)
+	case reflect.Bool:
+		b := uint64(0)
+		if v.Bool() {
+			b = 1
+		}
+		hashUint(h, 'b', b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		hashUint(h, 'i', uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		hashUint(h, 'u', v.Uint())
+	case reflect.Float32, reflect.Float64:
+		hashFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		hashFloat(h, real(c))
+		hashFloat(h, imag(c))
+	case reflect.String:
+		hashUint(h, 'S', uint64(v.Len()))
+		h.Write([]byte(v.String()))
+	case reflect.Slice, reflect.Array:
+		hashUint(h, 'a', uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i), seen)
+		}
+	case reflect.Map:
+		// Map iteration order is random so the entries are combined
+		// in an order independent way.
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			eh := fnv.New64a()
+			hashValue(eh, iter.Key(), seen)
+			hashValue(eh, iter.Value(), seen)
+			sum += eh.Sum64()
+		}
+		hashUint(h, 'm', uint64(v.Len()))
+		hashUint(h, 'm', sum)
+	case reflect.Struct:
+		hashUint(h, '{', uint64(v.NumField()))
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i), seen)
+		}
+	case reflect.Interface:
+		hashValue(h, v.Elem(), seen)
+	case reflect.Ptr:
+		if v.IsNil() {
+			hashUint(h, 'p', 0)
+			return
+		}
+		if seen[v.Pointer()] {
+			hashUint(h, 'c', 0)
+			return
+		}
+		seen[v.Pointer()] = true
+		hashValue(h, v.Elem(), seen)
+		delete(seen, v.Pointer())
+	default:
+		// Funcs are only equal when nil, channels and unsafe pointers
+		// when they are the same.
+		if v.IsNil() {
+			hashUint(h, 'p', 0)
+			return
+		}
+		hashUint(h, 'p', uint64(v.Pointer()))
+	}
+}
+
+// hashFloat hashes f so that 0 and -0, which compare equal, hash the
+// same.
+func hashFloat(h hash.Hash64, f float64) {
+	if f == 0 {
+		f = 0
+	}
+	hashUint(h, 'f', math.Float64bits(f))
+}
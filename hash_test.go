@@ -0,0 +1,52 @@
+package seq
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	built := Map(func(x int) int { return x - 1 }, Range(1, 4, 1))
+	if Hash(RangeUntil(3)) != Hash(built) ||
+		Hash(RangeUntil(3)) != Hash([]int{0, 1, 2}) {
+		t.Fatal("equal sequences hashed differently")
+	}
+	nested := Hash(PartitionAll(2, RangeUntil(4)))
+	if nested != Hash(Cons(Seq([]int{0, 1}), Cons(Seq([]int{2, 3}), nil))) {
+		t.Fatal("equal nested sequences hashed differently")
+	}
+	if Hash([]string{"ab", "c"}) == Hash([]string{"a", "bc"}) {
+		t.Fatal("element boundaries do not affect the hash")
+	}
+	if Hash(RangeUntil(3)) == Hash([]int{2, 1, 0}) {
+		t.Fatal("order does not affect the hash")
+	}
+	if Hash(nil) != Hash([]int{}) {
+		t.Fatal("empty sequences hashed differently")
+	}
+}
+
+func TestHashDeepEqual(t *testing.T) {
+	a, b := 1, 1
+	if !SequenceEqual([]*int{&a}, []*int{&b}) ||
+		Hash([]*int{&a}) != Hash([]*int{&b}) {
+		t.Fatal("equal pointer elements hashed differently")
+	}
+	c := 2
+	if Hash([]*int{&a}) == Hash([]*int{&c}) {
+		t.Fatal("different pointer elements hashed the same")
+	}
+	negZero := math.Copysign(0, -1)
+	if Hash([]float64{negZero}) != Hash([]float64{0}) {
+		t.Fatal("0 and -0 hashed differently")
+	}
+	m1 := map[string]int{"a": 1, "b": 2, "c": 3}
+	m2 := map[string]int{"c": 3, "b": 2, "a": 1}
+	if Hash([]interface{}{m1}) != Hash([]interface{}{m2}) {
+		t.Fatal("equal maps hashed differently")
+	}
+	type node struct{ next *node }
+	n := &node{}
+	n.next = n
+	Hash([]*node{n})
+}
@@ -0,0 +1,118 @@
+package seq
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+type lineSeq struct {
+	mu       sync.Mutex
+	sc       *bufio.Scanner
+	line     string
+	realized bool
+	next     Sequence
+}
+
+func lineSeqNew(sc *bufio.Scanner) Sequence {
+	if !sc.Scan() {
+		return nil
+	}
+	return &lineSeq{sc: sc, line: sc.Text()}
+}
+
+func (s *lineSeq) First() interface{} {
+	return s.line
+}
+
+func (s *lineSeq) Next() Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.realized {
+		s.next = lineSeqNew(s.sc)
+		s.realized = true
+		s.sc = nil
+	}
+	return s.next
+}
+
+func (s *lineSeq) String() string {
+	return seqString(s)
+}
+
+func (s *lineSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
+// LineSeq returns a lazy sequence of the lines read from r. Lines are
+// read as the sequence is realized and are cached so the sequence may
+// be traversed more than once. The line terminators are stripped as
+// by bufio.ScanLines. If reading fails the sequence ends early; use
+// LineSeqErr to find out why.
+func LineSeq(r io.Reader) Sequence {
+	s, _ := LineSeqErr(r)
+	return s
+}
+
+// LineSeqErr is like LineSeq but also returns a function reporting the
+// first non-EOF error encountered while reading r. The error is only
+// meaningful once the sequence has been fully realized.
+func LineSeqErr(r io.Reader) (Sequence, func() error) {
+	sc := bufio.NewScanner(r)
+	return LazySeq(func() Sequence {
+		return lineSeqNew(sc)
+	}), sc.Err
+}
+
+// DelimitedSeq returns a lazy sequence of the records decoded from r by
+// readOne. readOne is called to decode a single record each time the
+// sequence is extended and the sequence ends when it returns an error.
+// Records are cached so the sequence may be traversed more than once.
+// If decoding fails with an error other than io.EOF the sequence ends
+// early; use DelimitedSeqErr to find out why.
+func DelimitedSeq(
+	r io.Reader,
+	readOne func(io.Reader) (interface{}, error),
+) Sequence {
+	s, _ := DelimitedSeqErr(r, readOne)
+	return s
+}
+
+// DelimitedSeqErr is like DelimitedSeq but also returns a function
+// reporting the first non-EOF error returned by readOne. The error is
+// only meaningful once the sequence has been fully realized.
+func DelimitedSeqErr(
+	r io.Reader,
+	readOne func(io.Reader) (interface{}, error),
+) (Sequence, func() error) {
+	var mu sync.Mutex
+	var err error
+	setErr := func(e error) {
+		mu.Lock()
+		err = e
+		mu.Unlock()
+	}
+	getErr := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return err
+	}
+	return delimitedSeq(r, readOne, setErr), getErr
+}
+
+func delimitedSeq(
+	r io.Reader,
+	readOne func(io.Reader) (interface{}, error),
+	setErr func(error),
+) Sequence {
+	return LazySeq(func() Sequence {
+		v, err := readOne(r)
+		if err != nil {
+			if err != io.EOF {
+				setErr(err)
+			}
+			return nil
+		}
+		return Cons(v, delimitedSeq(r, readOne, setErr))
+	})
+}
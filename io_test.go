@@ -0,0 +1,117 @@
+package seq
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type countingReader struct {
+	r     io.Reader
+	reads int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads++
+	return r.r.Read(p[:1])
+}
+
+func TestLineSeq(t *testing.T) {
+	r := &countingReader{r: strings.NewReader("one\ntwo\nthree\n")}
+	seq := LineSeq(r)
+	if r.reads != 0 {
+		t.Fatal("reader was consumed before the sequence was realized")
+	}
+	if First(seq) != "one" {
+		t.Fatal("unexpected value", First(seq))
+	}
+	if r.reads != len("one\n") {
+		t.Fatal("read", r.reads, "bytes for the first line")
+	}
+	expected := []string{"one", "two", "three"}
+	for idx, line := range expected {
+		f := First(seq)
+		if f != line {
+			t.Fatal("wanted", line, "got", f, "at", idx)
+		}
+		seq = Next(seq)
+	}
+	if seq != nil {
+		t.Fatal("unexpected value", seq)
+	}
+}
+
+func TestLineSeqReiterable(t *testing.T) {
+	seq := LineSeq(strings.NewReader("a\nb\nc"))
+	first, second := fmt.Sprint(seq), fmt.Sprint(seq)
+	if first != "(a b c)" || first != second {
+		t.Fatal("unexpected value", first, second)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestLineSeqErr(t *testing.T) {
+	seq, errFn := LineSeqErr(io.MultiReader(strings.NewReader("a\nb\n"),
+		failingReader{}))
+	if got := fmt.Sprint(seq); got != "(a b)" {
+		t.Fatal("unexpected value", got)
+	}
+	if err := errFn(); err == nil || err.Error() != "read failed" {
+		t.Fatal("unexpected error", err)
+	}
+	seq, errFn = LineSeqErr(strings.NewReader("a\nb\n"))
+	DoRun(seq)
+	if err := errFn(); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func ExampleLineSeq() {
+	fmt.Println(LineSeq(strings.NewReader("one\ntwo\nthree\n")))
+	// Output: (one two three)
+}
+
+func readJSONLine(r io.Reader) (interface{}, error) {
+	line, err := r.(*bufio.Reader).ReadBytes('\n')
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		return nil, err
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(line, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func TestDelimitedSeq(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader(
+		"{\"id\":1}\n{\"id\":2}\n{\"id\":3}"))
+	got := Into([]float64{}, Map(func(v map[string]interface{}) float64 {
+		return v["id"].(float64)
+	}, DelimitedSeq(r, readJSONLine)))
+	if !reflect.DeepEqual(got, []float64{1, 2, 3}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestDelimitedSeqErr(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("{\"id\":1}\nnot json\n{\"id\":3}\n"))
+	s, errFn := DelimitedSeqErr(r, readJSONLine)
+	if got := len(Slice(s)); got != 1 {
+		t.Fatal("expected 1 record before the error got", got)
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(errFn(), &syntaxErr) {
+		t.Fatal("unexpected error", errFn())
+	}
+}
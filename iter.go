@@ -0,0 +1,54 @@
+package seq
+
+import (
+	"iter"
+)
+
+// Iter returns an iterator over the elements of coll for use with
+// range-over-func. Elements are realized as the iteration proceeds and
+// stopping the iteration early stops realizing elements. coll is any
+// type that can be converted to a Sequence by Seq.
+func Iter(coll interface{}) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+			if !yield(First(s)) {
+				return
+			}
+		}
+	}
+}
+
+// Iter2 is like Iter but the iterator also yields the index of each
+// element. coll is any type that can be converted to a Sequence by Seq.
+func Iter2(coll interface{}) iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		i := 0
+		for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+			if !yield(i, First(s)) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// FromIter returns a lazy sequence of the values produced by it. Values
+// are pulled from it as the sequence is realized and are cached so the
+// sequence may be traversed more than once while it is only run once.
+// If the sequence is not realized until it ends the iterator is left
+// suspended, so the resources it holds are not released.
+func FromIter(it iter.Seq[interface{}]) Sequence {
+	next, stop := iter.Pull(it)
+	return fromPull(next, stop)
+}
+
+func fromPull(next func() (interface{}, bool), stop func()) Sequence {
+	return LazySeq(func() Sequence {
+		v, ok := next()
+		if !ok {
+			stop()
+			return nil
+		}
+		return Cons(v, fromPull(next, stop))
+	})
+}
@@ -0,0 +1,73 @@
+package seq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIter(t *testing.T) {
+	var got []interface{}
+	for v := range Iter(RangeUntil(5)) {
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []interface{}{0, 1, 2, 3, 4}) {
+		t.Fatal("unexpected value", got)
+	}
+	got = nil
+	for v := range Iter(RepeateInfinitely("x")) {
+		if len(got) == 3 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []interface{}{"x", "x", "x"}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestIter2(t *testing.T) {
+	var idxs []int
+	var vals []interface{}
+	for i, v := range Iter2([]string{"a", "b", "c", "d"}) {
+		if i == 2 {
+			break
+		}
+		idxs = append(idxs, i)
+		vals = append(vals, v)
+	}
+	if !reflect.DeepEqual(idxs, []int{0, 1}) ||
+		!reflect.DeepEqual(vals, []interface{}{"a", "b"}) {
+		t.Fatal("unexpected values", idxs, vals)
+	}
+}
+
+func TestFromIter(t *testing.T) {
+	runs := 0
+	it := func(yield func(interface{}) bool) {
+		runs++
+		for i := 0; i < 4; i++ {
+			if !yield(i * i) {
+				return
+			}
+		}
+	}
+	s := FromIter(it)
+	first, second := Into([]int{}, s), Into([]int{}, s)
+	if !reflect.DeepEqual(first, []int{0, 1, 4, 9}) ||
+		!reflect.DeepEqual(second, first) {
+		t.Fatal("unexpected traversals", first, second)
+	}
+	if runs != 1 {
+		t.Fatal("expected the iterator to run once", runs)
+	}
+	if Seq(FromIter(func(func(interface{}) bool) {})) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func TestFromIterRoundTrip(t *testing.T) {
+	got := Into([]int{}, Take(3, FromIter(Iter(Range(1, 0, 0)))))
+	if !reflect.DeepEqual(got, []int{1, 1, 1}) {
+		t.Fatal("unexpected value", got)
+	}
+}
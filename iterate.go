@@ -2,6 +2,7 @@ package seq
 
 import (
 	"sync"
+	"time"
 )
 
 type iterate struct {
@@ -29,6 +30,8 @@ func (s *iterate) First() interface{} {
 func (s *iterate) first() interface{} {
 	if !s.realized {
 		s.cur = apply(s.fn, s.prev)
+		s.realized = true
+		s.prev = nil
 	}
 	return s.cur
 }
@@ -48,3 +51,34 @@ func (s *iterate) Next() Sequence {
 func (s *iterate) String() string {
 	return seqString(s)
 }
+
+func (s *iterate) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
+func retrying(fn interface{}, attempts int, backoff time.Duration) func(interface{}) interface{} {
+	return func(x interface{}) (out interface{}) {
+		for i := 0; ; i++ {
+			var failed bool
+			out, failed = tryApply(fn, x, i < attempts)
+			if !failed {
+				return out
+			}
+			time.Sleep(backoff)
+		}
+	}
+}
+
+// tryApply calls fn with x. If fn panics and recoverable is true the
+// panic is swallowed and failed is reported, otherwise the panic
+// propagates.
+func tryApply(fn, x interface{}, recoverable bool) (out interface{}, failed bool) {
+	if recoverable {
+		defer func() {
+			if r := recover(); r != nil {
+				failed = true
+			}
+		}()
+	}
+	return apply(fn, x), false
+}
@@ -0,0 +1,55 @@
+package seq
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// FromJSONArray parses data, which must be a JSON array, into a
+// sequence of its elements. Elements are decoded as by encoding/json
+// into an interface{}, so numbers become float64 and objects become
+// map[string]interface{}, except that nested arrays become nested
+// sequences. An empty array becomes a nil Sequence at any depth, as
+// with Seq, so it encodes back to JSON as null. Malformed
+// input, or input that is not an array, results in an error.
+func FromJSONArray(data []byte) (Sequence, error) {
+	var arr []interface{}
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return nil, err
+	}
+	return jsonArraySeq(arr), nil
+}
+
+// jsonSeqs converts the arrays nested within a decoded JSON value to
+// sequences.
+func jsonSeqs(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		for i, elem := range v {
+			if arr, ok := elem.([]interface{}); ok {
+				v[i] = jsonArraySeq(arr)
+			} else {
+				v[i] = jsonSeqs(elem)
+			}
+		}
+		return v
+	case map[string]interface{}:
+		for k, elem := range v {
+			if arr, ok := elem.([]interface{}); ok {
+				v[k] = jsonArraySeq(arr)
+			} else {
+				v[k] = jsonSeqs(elem)
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func jsonArraySeq(arr []interface{}) Sequence {
+	if len(arr) == 0 {
+		return nil
+	}
+	return sliceSequence(reflect.ValueOf(jsonSeqs(arr)))
+}
@@ -0,0 +1,119 @@
+package seq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"jsouthworth.net/go/transduce"
+)
+
+func closedChan(vals ...int) chan int {
+	ch := make(chan int, len(vals))
+	for _, v := range vals {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		exp  string
+	}{
+		{"range", RangeUntil(3), "[0,1,2]"},
+		{"slice", Seq([]string{"a", "b"}), `["a","b"]`},
+		{"cons", Cons(1, Cons("x", nil)), `[1,"x"]`},
+		{"xfrm", Map(func(x int) int { return x * 2 }, RangeUntil(3)), "[0,2,4]"},
+		{"lazy-empty", LazySeq(func() Sequence { return nil }), "[]"},
+		{"take-cycle", Take(3, Cycle([]int{1, 2})), "[1,2,1]"},
+		{"nested", PartitionAll(2, RangeUntil(5)), "[[0,1],[2,3],[4]]"},
+		{"nested-stable", Cons(Stable(RangeUntil(2)), nil), "[[0,1]]"},
+		{"stable", Stable(RangeUntil(2)), "[0,1]"},
+		{"eduction", Eduction(transduce.Map(func(x int) int { return x + 1 }),
+			RangeUntil(3)), "[1,2,3]"},
+		{"reiterable", ReIterable([]int{1, 2}), "[1,2]"},
+		{"repeatedly", Repeatedly(2, func() string { return "a" }), `["a","a"]`},
+		{"map", Seq(map[string]int{"a": 1}), `[["a",1]]`},
+		{"sorted-map", SortedMapSeqG(map[string]int{"b": 2, "a": 1}),
+			`[["a",1],["b",2]]`},
+		{"chan", Seq(closedChan(1, 2)), "[1,2]"},
+		{"float-range", RangeFloat(0, 1, 0.5), "[0,0.5]"},
+		{"lines", LineSeq(strings.NewReader("a\nb")), `["a","b"]`},
+		{"peekable", Peekable(RangeUntil(2)), "[0,1]"},
+		{"recurrence", Take(4, Recurrence(func(a, b int) int { return a + b }, 0, 1)),
+			"[0,1,1,2]"},
+		{"in-struct", struct {
+			Vals Sequence `json:"vals"`
+		}{RangeUntil(2)}, `{"vals":[0,1]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Marshal(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.exp {
+				t.Fatal("wanted", test.exp, "got", string(got))
+			}
+		})
+	}
+}
+
+func TestMarshalJSONError(t *testing.T) {
+	if _, err := json.Marshal(Seq([]interface{}{1, make(chan int)})); err == nil {
+		t.Fatal("expected error for unsupported element")
+	}
+}
+
+func TestFromJSONArray(t *testing.T) {
+	s, err := FromJSONArray([]byte(`[1, "a", [2, [3]], [], {"k": [4]}, null]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if First(s) != 1.0 || Second(s) != "a" {
+		t.Fatal("unexpected values", s)
+	}
+	if _, ok := First(Next(Next(s))).(Sequence); !ok {
+		t.Fatal("expected nested array to be a sequence")
+	}
+	out, err := marshalSeqJSON(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Seq(First(Next(Next(Next(s))))) != nil {
+		t.Fatal("expected nested empty array to be nil")
+	}
+	if exp := `[1,"a",[2,[3]],null,{"k":[4]},null]`; string(out) != exp {
+		t.Fatal("wanted", exp, "got", string(out))
+	}
+}
+
+func TestFromJSONArrayRoundTrip(t *testing.T) {
+	in := PartitionAll(2, RangeUntil(5))
+	data, err := marshalSeqJSON(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := FromJSONArray(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(s); got != "((0 1) (2 3) (4))" {
+		t.Fatal("unexpected value", got)
+	}
+	if s, err := FromJSONArray([]byte("[]")); err != nil || s != nil {
+		t.Fatal("expected empty sequence", s, err)
+	}
+}
+
+func TestFromJSONArrayMalformed(t *testing.T) {
+	for _, in := range []string{`[1, 2`, `{"a": 1}`, `1`, ``} {
+		if _, err := FromJSONArray([]byte(in)); err == nil {
+			t.Fatal("expected error for", in)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package seq
+
+// Juxt returns a function that applies each of fns to its argument and
+// returns the results in the same order as fns. Each fn must match the
+// signature func(in iT) oT and will be called using reflection unless
+// it is the non-specialized type func(interface{}) interface{}.
+func Juxt(fns ...interface{}) func(interface{}) []interface{} {
+	wrapped := make([]func(interface{}) interface{}, len(fns))
+	for i, fn := range fns {
+		wrapped[i] = wrapMapper(fn)
+	}
+	return func(in interface{}) []interface{} {
+		out := make([]interface{}, len(wrapped))
+		for i, fn := range wrapped {
+			out[i] = fn(in)
+		}
+		return out
+	}
+}
@@ -0,0 +1,28 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ExampleJuxt() {
+	fmt.Println(Map(Juxt(strings.ToUpper, func(s string) int {
+		return len(s)
+	}), []string{"a", "bb", "ccc"}))
+	// Output: ([A 1] [BB 2] [CCC 3])
+}
+
+func TestJuxt(t *testing.T) {
+	double := func(x int) int { return x * 2 }
+	square := func(x int) int { return x * x }
+	got := Into([][]interface{}{}, Map(Juxt(double, square), RangeUntil(4)))
+	exp := [][]interface{}{{0, 0}, {2, 1}, {4, 4}, {6, 9}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if got := Juxt()(1); len(got) != 0 {
+		t.Fatal("expected no results got", got)
+	}
+}
@@ -14,39 +14,66 @@ func LazySeq(fn func() Sequence) Sequence {
 	return &lazySeq{fn: fn}
 }
 
+// Seq realizes the sequence by calling fn at most once. The result is
+// resolved through any nested lazy sequences and cached, so later calls
+// to Seq, First and Next are O(1).
 func (s *lazySeq) Seq() Sequence {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.fn != nil {
-		s.seq = s.fn()
+		s.seq = Seq(s.fn())
 		s.fn = nil
 	}
-
-	var ls Sequence = s.seq
-	tmp, ok := ls.(*lazySeq)
-	for ok {
-		ls = tmp.Seq()
-		tmp, ok = ls.(*lazySeq)
-	}
-	s.seq = ls
-
 	return s.seq
 }
+
 func (s *lazySeq) First() interface{} {
-	s.Seq()
-	if s.seq == nil {
+	seq := s.Seq()
+	if seq == nil {
 		return nil
 	}
-	return First(s.seq)
+	return seq.First()
 }
+
 func (s *lazySeq) Next() Sequence {
-	s.Seq()
-	if s.seq == nil {
+	seq := s.Seq()
+	if seq == nil {
 		return nil
 	}
-	return Next(s.seq)
+	return seq.Next()
 }
 
 func (s *lazySeq) String() string {
 	return seqString(s)
 }
+
+func (s *lazySeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
+// LazyCat returns a lazy sequence that is the concatenation of the
+// sequences returned by fns. Each fn is only called once the sequence
+// returned by the previous fn has been exhausted. This allows
+// recursive and infinite sequences to be built without realizing them
+// eagerly.
+func LazyCat(fns ...func() Sequence) Sequence {
+	return LazySeq(func() Sequence {
+		for i, fn := range fns {
+			s := Seq(fn())
+			if s != nil {
+				return lazyCat(s, fns[i+1:])
+			}
+		}
+		return nil
+	})
+}
+
+func lazyCat(s Sequence, rest []func() Sequence) Sequence {
+	return Cons(First(s), LazySeq(func() Sequence {
+		next := Seq(Next(s))
+		if next == nil {
+			return LazyCat(rest...)
+		}
+		return lazyCat(next, rest)
+	}))
+}
@@ -0,0 +1,83 @@
+package seq
+
+import (
+	"container/list"
+	"sync"
+)
+
+// MapLRU returns a lazy sequence that is the result of applying fn to
+// each item of coll, like Map, while caching the results for the most
+// recently used capacity distinct arguments. Arguments found in the
+// cache reuse the earlier result instead of calling fn again, so fn
+// must be idempotent. Arguments that can't be used as map keys, such
+// as slices or structs holding slices in interface fields, bypass the
+// cache. fn must match the signature func(in iT) oT and will be called
+// using reflection unless it is the non-specialized type
+// func(interface{}) interface{}. coll is any type that can be converted
+// to a Sequence by Seq.
+func MapLRU(capacity int, fn interface{}, coll interface{}) Sequence {
+	f := wrapMapper(fn)
+	if capacity <= 0 {
+		return Map(f, coll)
+	}
+	cache := newLRU(capacity)
+	return Map(func(in interface{}) interface{} {
+		if !isHashable(in) {
+			return f(in)
+		}
+		if out, ok := cache.get(in); ok {
+			return out
+		}
+		out := f(in)
+		cache.put(in, out)
+		return out
+	}, coll)
+}
+
+type lruEntry struct {
+	key, val interface{}
+}
+
+// lru is a fixed capacity cache that evicts the least recently used
+// entry when full.
+type lru struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[interface{}]*list.Element
+}
+
+func newLRU(capacity int) *lru {
+	return &lru{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[interface{}]*list.Element),
+	}
+}
+
+func (c *lru) get(key interface{}) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*lruEntry).val, true
+}
+
+func (c *lru) put(key, val interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).val = val
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, val: val})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
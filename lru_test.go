@@ -0,0 +1,48 @@
+package seq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapLRU(t *testing.T) {
+	calls := 0
+	square := func(x int) int {
+		calls++
+		return x * x
+	}
+	in := []int{1, 2, 1, 2, 3, 1, 3, 3, 2}
+	got := Into([]int{}, MapLRU(2, square, in))
+	exp := []int{1, 4, 1, 4, 9, 1, 9, 9, 4}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	// 1, 2 miss; 1, 2 hit; 3 misses evicting 1; 1 misses evicting 2;
+	// 3, 3 hit; 2 misses.
+	if calls != 5 {
+		t.Fatal("unexpected number of calls", calls)
+	}
+}
+
+func TestMapLRUNonComparable(t *testing.T) {
+	calls := 0
+	got := Into([]int{}, MapLRU(4, func(x []int) int {
+		calls++
+		return len(x)
+	}, [][]int{{1}, {1}, {1, 2}}))
+	if !reflect.DeepEqual(got, []int{1, 1, 2}) {
+		t.Fatal("unexpected value", got)
+	}
+	if calls != 3 {
+		t.Fatal("unexpected number of calls", calls)
+	}
+	type key struct{ v interface{} }
+	calls = 0
+	got = Into([]int{}, MapLRU(4, func(k key) int {
+		calls++
+		return len(k.v.([]int))
+	}, []key{{[]int{1}}, {[]int{1}}}))
+	if !reflect.DeepEqual(got, []int{1, 1}) || calls != 2 {
+		t.Fatal("unexpected value", got, calls)
+	}
+}
@@ -0,0 +1,10 @@
+package seq
+
+// Memoize wraps coll so that each element is realized from it at most
+// once and then cached. The result may be traversed any number of
+// times, even when realizing coll has side effects or is expensive. It
+// is the same as Stable. coll is any type that can be converted to a
+// Sequence by Seq.
+func Memoize(coll interface{}) Sequence {
+	return Stable(coll)
+}
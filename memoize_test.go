@@ -0,0 +1,41 @@
+package seq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	s := Memoize(Repeatedly(4, func() int {
+		calls++
+		return calls * 10
+	}))
+	if calls != 0 {
+		t.Fatal("source realized before use")
+	}
+	first, second := Into([]int{}, s), Into([]int{}, s)
+	if !reflect.DeepEqual(first, []int{10, 20, 30, 40}) ||
+		!reflect.DeepEqual(second, first) {
+		t.Fatal("unexpected traversals", first, second)
+	}
+	if calls != 4 {
+		t.Fatal("source realized more than once", calls)
+	}
+}
+
+func TestMemoizeRecomputingSource(t *testing.T) {
+	calls := 0
+	s := Memoize(recomputingSeq{end: 5, calls: &calls})
+	DoRun(s)
+	DoRun(s)
+	if calls != 5 {
+		t.Fatal("expected each Next to be called once", calls)
+	}
+	if got := Into([]int{}, Take(2, s)); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Fatal("unexpected value", got)
+	}
+	if Seq(Memoize(nil)) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
@@ -0,0 +1,227 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MaxKey returns the element of coll for which keyFn returns the
+// largest value. If several elements have the largest key the last one
+// is returned. nil is returned for an empty coll. keyFn must match the
+// signature func(i iT) kT where kT is a numeric or string type, all
+// keys must be the same type. keyFn will be called using reflection
+// unless it is the non-specialized type func(interface{}) interface{}.
+// coll is any type that can be converted to a Sequence by Seq.
+func MaxKey(keyFn interface{}, coll interface{}) interface{} {
+	return extremeKey(keyFn, coll, func(k, best interface{}) bool {
+		return !lessNumbers(k, best)
+	})
+}
+
+// MinKey returns the element of coll for which keyFn returns the
+// smallest value. If several elements have the smallest key the first
+// one is returned. nil is returned for an empty coll. keyFn must match
+// the signature func(i iT) kT where kT is a numeric or string type, all
+// keys must be the same type. keyFn will be called using reflection
+// unless it is the non-specialized type func(interface{}) interface{}.
+// coll is any type that can be converted to a Sequence by Seq.
+func MinKey(keyFn interface{}, coll interface{}) interface{} {
+	return extremeKey(keyFn, coll, lessNumbers)
+}
+
+// Sum returns the sum of the elements of coll. The elements must all be
+// the same numeric type and the result is of that type. The sum of an
+// empty coll is the int 0. coll is any type that can be converted to a
+// Sequence by Seq.
+func Sum(coll interface{}) interface{} {
+	return foldNumbers("Sum", 0, addNumbers, coll)
+}
+
+// Product returns the product of the elements of coll. The elements
+// must all be the same numeric type and the result is of that type. The
+// product of an empty coll is the int 1. coll is any type that can be
+// converted to a Sequence by Seq.
+func Product(coll interface{}) interface{} {
+	return foldNumbers("Product", 1, mulNumbers, coll)
+}
+
+// Max returns the largest element of coll. The elements must all be the
+// same numeric type. nil is returned for an empty coll. coll is any
+// type that can be converted to a Sequence by Seq.
+func Max(coll interface{}) interface{} {
+	return foldNumbers("Max", nil, func(a, b interface{}) interface{} {
+		if lessNumbers(a, b) {
+			return b
+		}
+		return a
+	}, coll)
+}
+
+// Min returns the smallest element of coll. The elements must all be
+// the same numeric type. nil is returned for an empty coll. coll is any
+// type that can be converted to a Sequence by Seq.
+func Min(coll interface{}) interface{} {
+	return foldNumbers("Min", nil, func(a, b interface{}) interface{} {
+		if lessNumbers(b, a) {
+			return b
+		}
+		return a
+	}, coll)
+}
+
+// SumBy returns the sum of the result of applying fn to each element of
+// coll as a float64. fn must match the signature func(i iT) nT where nT
+// is a numeric type. It will be called using reflection unless it is
+// the non-specialized type func(interface{}) interface{}. coll is any
+// type that can be converted to a Sequence by Seq.
+func SumBy(fn interface{}, coll interface{}) float64 {
+	sum, _ := sumBy("SumBy", fn, coll)
+	return sum
+}
+
+// AverageBy returns the mean of the result of applying fn to each
+// element of coll as a float64, or 0 if coll is empty. fn must match
+// the signature func(i iT) nT where nT is a numeric type. It will be
+// called using reflection unless it is the non-specialized type
+// func(interface{}) interface{}. coll is any type that can be converted
+// to a Sequence by Seq.
+func AverageBy(fn interface{}, coll interface{}) float64 {
+	sum, n := sumBy("AverageBy", fn, coll)
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+func sumBy(name string, fn interface{}, coll interface{}) (float64, int) {
+	f := wrapMapper(fn)
+	var sum float64
+	n := 0
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		sum += toFloat64(name, f(First(s)))
+		n++
+	}
+	return sum, n
+}
+
+// toFloat64 converts a number of any numeric type to a float64.
+func toFloat64(name string, x interface{}) float64 {
+	v := reflect.ValueOf(x)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		panic(fmt.Errorf("seq.%s: non-numeric value of type %T", name, x))
+	}
+}
+
+// foldNumbers combines the elements of coll with fn after checking they
+// are all of the same numeric type. empty is returned if coll is empty.
+func foldNumbers(
+	name string,
+	empty interface{},
+	fn func(a, b interface{}) interface{},
+	coll interface{},
+) interface{} {
+	s := Seq(coll)
+	if s == nil {
+		return empty
+	}
+	res := First(s)
+	if !isNumber(res) {
+		panic(fmt.Errorf("seq.%s: non-numeric element of type %T", name, res))
+	}
+	for s = Seq(Next(s)); s != nil; s = Seq(Next(s)) {
+		x := First(s)
+		if reflect.TypeOf(x) != reflect.TypeOf(res) {
+			panic(fmt.Errorf("seq.%s: mixed element types %T and %T",
+				name, res, x))
+		}
+		res = fn(res, x)
+	}
+	return res
+}
+
+func isNumber(x interface{}) bool {
+	switch reflect.ValueOf(x).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// mulNumbers multiplies two numbers of the same type.
+func mulNumbers(a, b interface{}) interface{} {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() || av.Type() != bv.Type() {
+		panic(fmt.Errorf("cannot multiply %T and %T", a, b))
+	}
+	out := reflect.New(av.Type()).Elem()
+	switch av.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		out.SetInt(av.Int() * bv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		out.SetUint(av.Uint() * bv.Uint())
+	case reflect.Float32, reflect.Float64:
+		out.SetFloat(av.Float() * bv.Float())
+	default:
+		panic(fmt.Errorf("cannot multiply non-numeric type %T", a))
+	}
+	return out.Interface()
+}
+
+// extremeKey returns the element whose key replaces the best key so far
+// according to better.
+func extremeKey(
+	keyFn interface{},
+	coll interface{},
+	better func(k, best interface{}) bool,
+) interface{} {
+	key := wrapMapper(keyFn)
+	var best, bestKey interface{}
+	started := false
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		elem := First(s)
+		k := key(elem)
+		if !started || better(k, bestKey) {
+			best, bestKey = elem, k
+			started = true
+		}
+	}
+	return best
+}
+
+// lessNumbers reports whether a is less than b. a and b must be the
+// same numeric or string type.
+func lessNumbers(a, b interface{}) bool {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() || av.Type() != bv.Type() {
+		panic(fmt.Errorf("cannot compare %T and %T", a, b))
+	}
+	switch av.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		return av.Int() < bv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return av.Uint() < bv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return av.Float() < bv.Float()
+	case reflect.String:
+		return av.String() < bv.String()
+	default:
+		panic(fmt.Errorf("cannot compare non-ordered type %T", a))
+	}
+}
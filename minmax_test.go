@@ -0,0 +1,168 @@
+package seq
+
+import (
+	"fmt"
+	"testing"
+	"testing/quick"
+)
+
+func TestMaxKey(t *testing.T) {
+	type pair struct {
+		idx int
+		key int8
+	}
+	if err := quick.Check(func(keys []int8) bool {
+		pairs := make([]pair, len(keys))
+		var exp interface{}
+		for i, k := range keys {
+			pairs[i] = pair{i, k}
+			if exp == nil || k >= exp.(pair).key {
+				exp = pairs[i]
+			}
+		}
+		return MaxKey(func(p pair) int8 { return p.key }, pairs) == exp
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMinKey(t *testing.T) {
+	type pair struct {
+		idx int
+		key float64
+	}
+	if err := quick.Check(func(keys []float64) bool {
+		pairs := make([]pair, len(keys))
+		var exp interface{}
+		for i, k := range keys {
+			pairs[i] = pair{i, k}
+			if exp == nil || k < exp.(pair).key {
+				exp = pairs[i]
+			}
+		}
+		return MinKey(func(p pair) float64 { return p.key }, pairs) == exp
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMaxKeyTies(t *testing.T) {
+	words := []string{"bb", "a", "cc", "dd", "e"}
+	length := func(s string) int { return len(s) }
+	if got := MaxKey(length, words); got != "dd" {
+		t.Fatal("expected last maximum got", got)
+	}
+	if got := MinKey(length, words); got != "a" {
+		t.Fatal("expected first minimum got", got)
+	}
+	if got := MaxKey(length, nil); got != nil {
+		t.Fatal("expected nil for empty input got", got)
+	}
+}
+
+func ExampleSum() {
+	fmt.Println(Sum(RangeUntil(5)))
+	fmt.Println(Sum([]float64{0.5, 1.5, 2}))
+	// Output: 10
+	// 4
+}
+
+func ExampleProduct() {
+	fmt.Println(Product([]int64{2, 3, 4}))
+	// Output: 24
+}
+
+func ExampleMax() {
+	fmt.Println(Max([]float64{2.5, -1, 7.25, 3}))
+	// Output: 7.25
+}
+
+func ExampleMin() {
+	fmt.Println(Min([]int{4, -2, 9}))
+	// Output: -2
+}
+
+func TestNumericAggregates(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(interface{}) interface{}
+		in   interface{}
+		exp  interface{}
+	}{
+		{"sum-int", Sum, []int{1, 2, 3}, 6},
+		{"sum-int64", Sum, []int64{1, 2, 3}, int64(6)},
+		{"sum-float64", Sum, []float64{1, 2.5}, 3.5},
+		{"sum-empty", Sum, nil, 0},
+		{"product-int", Product, []int{2, 3}, 6},
+		{"product-int64", Product, []int64{2, 3}, int64(6)},
+		{"product-float64", Product, []float64{0.5, 3}, 1.5},
+		{"product-empty", Product, nil, 1},
+		{"max-int", Max, []int{3, 9, 1}, 9},
+		{"max-int64", Max, []int64{3, 9, 1}, int64(9)},
+		{"max-float64", Max, []float64{-3, -0.5}, -0.5},
+		{"max-empty", Max, nil, nil},
+		{"min-int", Min, []int{3, 9, 1}, 1},
+		{"min-int64", Min, []int64{3, 9, 1}, int64(1)},
+		{"min-float64", Min, []float64{-3, -0.5}, -3.0},
+		{"min-empty", Min, nil, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.fn(test.in); got != test.exp {
+				t.Fatalf("wanted %v (%T) got %v (%T)",
+					test.exp, test.exp, got, got)
+			}
+		})
+	}
+}
+
+func TestNumericAggregatesPanic(t *testing.T) {
+	tests := map[string]func(){
+		"mixed":       func() { Sum([]interface{}{1, 2.0}) },
+		"non-numeric": func() { Max([]string{"a", "b"}) },
+		"mixed-min":   func() { Min([]interface{}{int64(1), 1}) },
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+func TestSumByAverageBy(t *testing.T) {
+	if err := quick.Check(func(is []int16) bool {
+		var sum float64
+		for _, v := range is {
+			sum += float64(v) * 2
+		}
+		avg := 0.0
+		if len(is) > 0 {
+			avg = sum / float64(len(is))
+		}
+		double := func(x int16) int { return int(x) * 2 }
+		return SumBy(double, is) == sum && AverageBy(double, is) == avg
+	}, nil); err != nil {
+		t.Error(err)
+	}
+	if err := quick.Check(func(fs []float32) bool {
+		var sum float64
+		for _, v := range fs {
+			sum += float64(v) / 2
+		}
+		half := func(x float32) float64 { return float64(x) / 2 }
+		return SumBy(half, fs) == sum
+	}, nil); err != nil {
+		t.Error(err)
+	}
+	if got := AverageBy(func(x int) int { return x }, nil); got != 0 {
+		t.Fatal("expected 0 for empty input got", got)
+	}
+	if got := AverageBy(func(x int) int { return x }, RangeUntil(4)); got != 1.5 {
+		t.Fatal("unexpected average", got)
+	}
+}
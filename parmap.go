@@ -0,0 +1,71 @@
+package seq
+
+// ParMap returns a lazy sequence that contains the result of applying
+// fn to each item of coll, like Map, but computes the results
+// concurrently using up to workers goroutines. The results are in the
+// same order as the items of coll. Work is only started for at most
+// workers items beyond the last consumed result so an infinite coll
+// does not cause unbounded work. If fn panics the panic is raised when
+// the corresponding result is consumed. fn must match the signature
+// func(in iT) oT and will be called using reflection unless it is the
+// non-specialized type func(interface{}) interface{}. coll is any type
+// that can be converted to a Sequence by Seq.
+func ParMap(workers int, fn interface{}, coll interface{}) Sequence {
+	if workers <= 0 {
+		workers = 1
+	}
+	f := wrapMapper(fn)
+	return LazySeq(func() Sequence {
+		futures := Map(func(in interface{}) interface{} {
+			return startFuture(f, in)
+		}, coll)
+		// Realizing the futures starts them, ahead is kept workers-1
+		// items past the one being consumed.
+		ahead := Seq(futures)
+		for i := 1; i < workers && ahead != nil; i++ {
+			ahead = Seq(Next(ahead))
+		}
+		return parMapStep(futures, ahead)
+	})
+}
+
+func parMapStep(futures, ahead Sequence) Sequence {
+	return LazySeq(func() Sequence {
+		s := Seq(futures)
+		if s == nil {
+			return nil
+		}
+		out := First(s).(*future).get()
+		if ahead != nil {
+			ahead = Seq(Next(ahead))
+		}
+		return Cons(out, parMapStep(Next(s), ahead))
+	})
+}
+
+// future is the result of a function call running in its own goroutine.
+type future struct {
+	done  chan struct{}
+	val   interface{}
+	panic interface{}
+}
+
+func startFuture(fn func(interface{}) interface{}, in interface{}) *future {
+	f := &future{done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		defer func() {
+			f.panic = recover()
+		}()
+		f.val = fn(in)
+	}()
+	return f
+}
+
+func (f *future) get() interface{} {
+	<-f.done
+	if f.panic != nil {
+		panic(f.panic)
+	}
+	return f.val
+}
@@ -0,0 +1,56 @@
+package seq
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParMap(t *testing.T) {
+	slow := func(x int) int {
+		time.Sleep(20 * time.Millisecond)
+		return x * x
+	}
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	start := time.Now()
+	got := Into([]int{}, ParMap(4, slow, in))
+	elapsed := time.Since(start)
+	exp := Into([]int{}, Map(func(x int) int { return x * x }, in))
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if sequential := time.Duration(len(in)) * 20 * time.Millisecond; elapsed >= sequential {
+		t.Fatal("expected parallel speedup, took", elapsed)
+	}
+}
+
+func TestParMapBounded(t *testing.T) {
+	var started int32
+	s := ParMap(2, func(x int) int {
+		atomic.AddInt32(&started, 1)
+		return x
+	}, Range(0, 1, 0))
+	got := Into([]int{}, Take(3, s))
+	if !reflect.DeepEqual(got, []int{0, 0, 0}) {
+		t.Fatal("unexpected value", got)
+	}
+	if n := atomic.LoadInt32(&started); n > 5 {
+		t.Fatal("too much work started", n)
+	}
+}
+
+func TestParMapPanic(t *testing.T) {
+	s := ParMap(2, func(x int) int {
+		if x == 2 {
+			panic("boom")
+		}
+		return x
+	}, []int{1, 2, 3})
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatal("unexpected panic", r)
+		}
+	}()
+	DoRun(s)
+}
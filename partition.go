@@ -0,0 +1,158 @@
+package seq
+
+// Partition returns a lazy sequence of windows of n elements of coll,
+// with each window starting step elements after the previous one. When
+// step is less than n the windows overlap and when it is greater
+// elements are skipped. A trailing window with fewer than n elements is
+// dropped. Each window is a realized Sequence. coll is any type that
+// can be converted to a Sequence by Seq.
+func Partition(n, step int, coll interface{}) Sequence {
+	return partition(n, step, nil, false, coll)
+}
+
+// PartitionPad is Partition except that a trailing short window is
+// filled with elements of pad up to n elements instead of being
+// dropped. If pad does not have enough elements the last window will
+// have fewer than n elements. pad and coll are any type that can be
+// converted to a Sequence by Seq.
+func PartitionPad(n, step int, pad interface{}, coll interface{}) Sequence {
+	return partition(n, step, pad, true, coll)
+}
+
+// SlidingWindow returns a lazy sequence of every window of n
+// consecutive elements of coll. A coll of m elements yields m-n+1
+// windows and no windows when it has fewer than n elements. It is
+// equivalent to Partition(n, 1, coll). coll is any type that can be
+// converted to a Sequence by Seq.
+func SlidingWindow(n int, coll interface{}) Sequence {
+	return Partition(n, 1, coll)
+}
+
+// WindowedReduce returns a lazy sequence of the result of reducing each
+// window of n consecutive elements of coll, as returned by
+// SlidingWindow, with fn. The first element of each window is used as
+// the initial value of its reduction, so a window of one element
+// reduces to that element. The reducing function 'fn' must match the
+// signature func(result rT, input iT) rT and will be called using
+// reflection unless it is the non-specialized type
+// func(result, input interface{}) interface{}. coll is any type that
+// can be converted to a Sequence by Seq.
+func WindowedReduce(n int, fn interface{}, coll interface{}) Sequence {
+	rFn := wrapReduce(fn)
+	return Map(func(window interface{}) interface{} {
+		s := Seq(window)
+		return Reduce(rFn, First(s), Next(s))
+	}, SlidingWindow(n, coll))
+}
+
+func partition(
+	n, step int,
+	pad interface{},
+	padded bool,
+	coll interface{},
+) Sequence {
+	if n <= 0 || step <= 0 {
+		return nil
+	}
+	return LazySeq(func() Sequence {
+		s := Seq(coll)
+		if s == nil {
+			return nil
+		}
+		window := make([]interface{}, 0, n)
+		for e := s; e != nil && len(window) < n; e = Seq(Next(e)) {
+			window = append(window, First(e))
+		}
+		if len(window) < n {
+			if !padded {
+				return nil
+			}
+			for p := Seq(pad); p != nil && len(window) < n; p = Seq(Next(p)) {
+				window = append(window, First(p))
+			}
+			return Cons(Seq(window), nil)
+		}
+		return Cons(Seq(window),
+			partition(n, step, pad, padded, nthNext(s, step)))
+	})
+}
+
+// Batch returns a lazy sequence of []interface{} slices each holding up
+// to n consecutive elements of coll. Only the last batch may have fewer
+// than n elements. Unlike PartitionAll the batches are realized slices
+// that can be passed directly to APIs taking slices. coll is any type
+// that can be converted to a Sequence by Seq.
+func Batch(n int, coll interface{}) Sequence {
+	if n <= 0 {
+		return nil
+	}
+	return LazySeq(func() Sequence {
+		s := Seq(coll)
+		if s == nil {
+			return nil
+		}
+		batch := make([]interface{}, 0, n)
+		for ; s != nil && len(batch) < n; s = Seq(Next(s)) {
+			batch = append(batch, First(s))
+		}
+		return Cons(batch, Batch(n, s))
+	})
+}
+
+// PartitionInto returns a lazy sequence of exactly k sub-sequences
+// splitting the elements of coll in order as evenly as possible. When
+// the elements don't divide evenly the earlier groups hold one extra
+// element, and when there are fewer than k elements the trailing groups
+// are empty. This is useful for sharding work across k workers. Each
+// group is a realized Sequence. coll must be finite and is any type
+// that can be converted to a Sequence by Seq.
+func PartitionInto(k int, coll interface{}) Sequence {
+	if k <= 0 {
+		return nil
+	}
+	return LazySeq(func() Sequence {
+		elems := Slice(coll)
+		size, extra := len(elems)/k, len(elems)%k
+		groups := make([]interface{}, k)
+		for i := range groups {
+			n := size
+			if i < extra {
+				n++
+			}
+			groups[i] = Seq(elems[:n])
+			elems = elems[n:]
+		}
+		return Seq(groups)
+	})
+}
+
+// PartitionIndexed returns a lazy sequence of
+// []interface{}{partitionIndex, item} pairs for each item of coll, where
+// partitionIndex starts at 0 and increments every n items. This assigns
+// batch numbers to the items without realizing the partitions. coll is
+// any type that can be converted to a Sequence by Seq.
+func PartitionIndexed(n int, coll interface{}) Sequence {
+	if n <= 0 {
+		return nil
+	}
+	return partitionIndexed(n, 0, coll)
+}
+
+func partitionIndexed(n, i int, coll interface{}) Sequence {
+	return LazySeq(func() Sequence {
+		s := Seq(coll)
+		if s == nil {
+			return nil
+		}
+		return Cons([]interface{}{i / n, First(s)},
+			partitionIndexed(n, i+1, Next(s)))
+	})
+}
+
+// nthNext returns the sequence remaining after skipping n elements of s.
+func nthNext(s Sequence, n int) Sequence {
+	for ; s != nil && n > 0; n-- {
+		s = Seq(Next(s))
+	}
+	return s
+}
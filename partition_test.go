@@ -0,0 +1,159 @@
+package seq
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func ExamplePartition() {
+	fmt.Println(Partition(3, 1, RangeUntil(5)))
+	fmt.Println(Partition(2, 3, RangeUntil(8)))
+	// Output: ((0 1 2) (1 2 3) (2 3 4))
+	// ((0 1) (3 4) (6 7))
+}
+
+func ExamplePartitionPad() {
+	fmt.Println(PartitionPad(3, 3, []string{"a", "b"}, RangeUntil(7)))
+	fmt.Println(PartitionPad(4, 4, nil, RangeUntil(6)))
+	// Output: ((0 1 2) (3 4 5) (6 a b))
+	// ((0 1 2 3) (4 5))
+}
+
+func ExampleSlidingWindow() {
+	fmt.Println(SlidingWindow(3, RangeUntil(5)))
+	// Output: ((0 1 2) (1 2 3) (2 3 4))
+}
+
+func TestSlidingWindowShort(t *testing.T) {
+	if Seq(SlidingWindow(3, RangeUntil(2))) != nil {
+		t.Fatal("expected no windows for short input")
+	}
+	if Seq(SlidingWindow(3, nil)) != nil {
+		t.Fatal("expected no windows for empty input")
+	}
+	if got := len(Slice(SlidingWindow(3, RangeUntil(3)))); got != 1 {
+		t.Fatal("expected a single window got", got)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	got := Into([][]interface{}{}, Map(Slice, Partition(2, 2, RangeUntil(5))))
+	exp := [][]interface{}{{0, 1}, {2, 3}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if Seq(Partition(3, 1, RangeUntil(2))) != nil {
+		t.Fatal("expected no windows for short input")
+	}
+	if Seq(Partition(0, 1, RangeUntil(2))) != nil {
+		t.Fatal("expected no windows for n of 0")
+	}
+}
+
+func TestPartitionInfinite(t *testing.T) {
+	got := Into([][]interface{}{}, Map(Slice,
+		Take(2, Partition(2, 1, Iterate(func(x int) int {
+			return x + 1
+		}, 0)))))
+	exp := [][]interface{}{{0, 1}, {1, 2}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func TestPartitionIndexed(t *testing.T) {
+	got := Into([]int{}, Map(First, PartitionIndexed(3, RangeUntil(7))))
+	exp := []int{0, 0, 0, 1, 1, 1, 2}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	got = Into([]int{}, Map(Second, PartitionIndexed(3, RangeUntil(7))))
+	exp = []int{0, 1, 2, 3, 4, 5, 6}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func ExampleBatch() {
+	fmt.Println(Batch(4, RangeUntil(10)))
+	// Output: ([0 1 2 3] [4 5 6 7] [8 9])
+}
+
+func TestBatch(t *testing.T) {
+	batches := Slice(Batch(4, RangeUntil(10)))
+	if len(batches) != 3 {
+		t.Fatal("unexpected number of batches", len(batches))
+	}
+	if last := batches[2].([]interface{}); !reflect.DeepEqual(last,
+		[]interface{}{8, 9}) {
+		t.Fatal("unexpected final batch", last)
+	}
+	if got := len(Slice(Batch(5, RangeUntil(10)))); got != 2 {
+		t.Fatal("expected exact batches got", got)
+	}
+	if Seq(Batch(3, nil)) != nil || Seq(Batch(0, RangeUntil(3))) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func ExampleWindowedReduce() {
+	fmt.Println(WindowedReduce(2, func(a, b int) int {
+		return a + b
+	}, RangeUntil(5)))
+	// Output: (1 3 5 7)
+}
+
+func TestWindowedReduce(t *testing.T) {
+	if err := quick.Check(func(is []int16, n uint8) bool {
+		size := int(n%5) + 1
+		var exp []int
+		for i := 0; i+size <= len(is); i++ {
+			max := int(is[i])
+			for _, v := range is[i+1 : i+size] {
+				if int(v) > max {
+					max = int(v)
+				}
+			}
+			exp = append(exp, max)
+		}
+		got := Into([]int(nil), WindowedReduce(size, func(a, b int) int {
+			if b > a {
+				return b
+			}
+			return a
+		}, Map(func(x int16) int { return int(x) }, is))).([]int)
+		return reflect.DeepEqual(got, exp)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func ExamplePartitionInto() {
+	fmt.Println(PartitionInto(3, RangeUntil(10)))
+	// Output: ((0 1 2 3) (4 5 6) (7 8 9))
+}
+
+func TestPartitionInto(t *testing.T) {
+	var sizes []int
+	var all []interface{}
+	Doseq(func(group interface{}) {
+		sizes = append(sizes, Count(group))
+		all = append(all, Slice(group)...)
+	}, PartitionInto(3, RangeUntil(10)))
+	if !reflect.DeepEqual(sizes, []int{4, 3, 3}) {
+		t.Fatal("unexpected group sizes", sizes)
+	}
+	if !reflect.DeepEqual(all, Slice(RangeUntil(10))) {
+		t.Fatal("unexpected elements", all)
+	}
+	groups := Slice(PartitionInto(4, RangeUntil(2)))
+	if len(groups) != 4 || Count(groups[0]) != 1 ||
+		Count(groups[1]) != 1 || Seq(groups[3]) != nil {
+		t.Fatal("unexpected groups", groups)
+	}
+	if Seq(PartitionInto(0, RangeUntil(3))) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
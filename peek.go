@@ -0,0 +1,74 @@
+package seq
+
+import (
+	"sync"
+)
+
+// PeekableSequence is a Sequence that can look at the element after its
+// first without moving past it.
+type PeekableSequence interface {
+	Sequence
+	// Peek returns the element that First will return on the result
+	// of Next, or nil if there is no next element.
+	Peek() interface{}
+}
+
+type peekable struct {
+	mu           sync.Mutex
+	seq          Sequence
+	next         *peekable
+	nextRealized bool
+}
+
+// Peekable returns a sequence of the elements of coll that allows the
+// next element to be inspected with Peek. The elements are cached as
+// by Stable so peeking and then moving on with Next never skips or
+// repeats an element. coll is any type that can be converted to a
+// Sequence by Seq.
+func Peekable(coll interface{}) PeekableSequence {
+	s := Stable(coll)
+	if s == nil {
+		return nil
+	}
+	return &peekable{seq: s}
+}
+
+func (p *peekable) First() interface{} {
+	return p.seq.First()
+}
+
+func (p *peekable) Peek() interface{} {
+	next := p.realizeNext()
+	if next == nil {
+		return nil
+	}
+	return next.First()
+}
+
+func (p *peekable) Next() Sequence {
+	next := p.realizeNext()
+	if next == nil {
+		return nil
+	}
+	return next
+}
+
+func (p *peekable) realizeNext() *peekable {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.nextRealized {
+		if next := p.seq.Next(); next != nil {
+			p.next = &peekable{seq: next}
+		}
+		p.nextRealized = true
+	}
+	return p.next
+}
+
+func (p *peekable) String() string {
+	return seqString(p)
+}
+
+func (p *peekable) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(p)
+}
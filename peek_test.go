@@ -0,0 +1,48 @@
+package seq
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPeekable(t *testing.T) {
+	calls := 0
+	p := Peekable(recomputingSeq{end: 5, calls: &calls})
+	var got []interface{}
+	for s := p; s != nil; {
+		if peeked, next := s.Peek(), s.Next(); next != nil {
+			if peeked != next.First() {
+				t.Fatal("peeked", peeked, "but next is", next.First())
+			}
+			if s.Peek() != peeked {
+				t.Fatal("peek moved the sequence")
+			}
+		} else if peeked != nil {
+			t.Fatal("peeked", peeked, "past the end")
+		}
+		got = append(got, s.First())
+		next := s.Next()
+		if next == nil {
+			break
+		}
+		s = next.(PeekableSequence)
+	}
+	if fmt.Sprint(got) != "[0 1 4 9 16]" {
+		t.Fatal("unexpected value", got)
+	}
+	if calls != 5 {
+		t.Fatal("Next called", calls, "times, expected 5")
+	}
+	if Peekable(nil) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func ExamplePeekable() {
+	p := Peekable([]string{"let", "x", "=", "1"})
+	fmt.Println(p.First(), p.Peek())
+	fmt.Println(p)
+	// Output:
+	// let x
+	// (let x = 1)
+}
@@ -2,9 +2,10 @@ package seq
 
 type rangeSeq struct {
 	start, end, step int
+	i                int
 }
 
-func rangeNew(start, end, step int) Sequence {
+func rangeNew(start, end, step, i int) Sequence {
 	switch {
 	case step > 0:
 		if start >= end {
@@ -23,6 +24,7 @@ func rangeNew(start, end, step int) Sequence {
 		start: start,
 		end:   end,
 		step:  step,
+		i:     i,
 	}
 }
 
@@ -31,7 +33,12 @@ func (s *rangeSeq) First() interface{} {
 }
 
 func (s *rangeSeq) Next() Sequence {
-	new := rangeNew(s.start+s.step, s.end, s.step)
+	next := s.start + s.step
+	if (s.step > 0 && next < s.start) || (s.step < 0 && next > s.start) {
+		// start+step overflowed so it is past end
+		return nil
+	}
+	new := rangeNew(next, s.end, s.step, s.i+1)
 	if new == nil {
 		return nil
 	}
@@ -41,3 +48,62 @@ func (s *rangeSeq) Next() Sequence {
 func (s *rangeSeq) String() string {
 	return seqString(s)
 }
+
+func (s *rangeSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
+type rangeFloatSeq struct {
+	start, end, step float64
+	i                int
+}
+
+func rangeFloatNew(start, end, step float64, i int) Sequence {
+	v := start + float64(i)*step
+	switch {
+	case step > 0:
+		if v >= end {
+			return nil
+		}
+	case step < 0:
+		if v <= end {
+			return nil
+		}
+	default: //step == 0
+		if start == end {
+			return nil
+		}
+	}
+	return &rangeFloatSeq{
+		start: start,
+		end:   end,
+		step:  step,
+		i:     i,
+	}
+}
+
+// RangeFloat returns a lazy sequence that will be the floats
+// [start, start+step, ..., end). Like Range the sequence is empty if
+// step moves away from end. Each element is computed as start+i*step
+// rather than by repeatedly adding step so rounding error does not
+// accumulate, but elements are still subject to floating point
+// rounding and one may land just short of or just past end.
+func RangeFloat(start, end, step float64) Sequence {
+	return rangeFloatNew(start, end, step, 0)
+}
+
+func (s *rangeFloatSeq) First() interface{} {
+	return s.start + float64(s.i)*s.step
+}
+
+func (s *rangeFloatSeq) Next() Sequence {
+	return rangeFloatNew(s.start, s.end, s.step, s.i+1)
+}
+
+func (s *rangeFloatSeq) String() string {
+	return seqString(s)
+}
+
+func (s *rangeFloatSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
@@ -0,0 +1,66 @@
+package seq
+
+import (
+	"sync"
+)
+
+type recurrence struct {
+	mu       sync.Mutex
+	realized bool
+	cur      interface{}
+	prev     []interface{}
+	fn       interface{}
+	next     *recurrence
+}
+
+func recurrenceNew(fn interface{}, seeds ...interface{}) Sequence {
+	prev := make([]interface{}, len(seeds))
+	copy(prev, seeds)
+	var out Sequence = &recurrence{
+		fn:   fn,
+		prev: prev,
+	}
+	for i := len(seeds) - 1; i >= 0; i-- {
+		out = Cons(seeds[i], out)
+	}
+	return out
+}
+
+func (s *recurrence) First() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.first()
+}
+
+func (s *recurrence) first() interface{} {
+	if !s.realized {
+		s.cur = apply(s.fn, s.prev...)
+		s.realized = true
+	}
+	return s.cur
+}
+
+func (s *recurrence) Next() Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.next == nil {
+		prev := make([]interface{}, len(s.prev))
+		if len(prev) > 0 {
+			copy(prev, s.prev[1:])
+			prev[len(prev)-1] = s.first()
+		}
+		s.next = &recurrence{
+			fn:   s.fn,
+			prev: prev,
+		}
+	}
+	return s.next
+}
+
+func (s *recurrence) String() string {
+	return seqString(s)
+}
+
+func (s *recurrence) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
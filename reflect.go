@@ -1,12 +1,16 @@
 package seq
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+
+	"jsouthworth.net/go/transduce"
 )
 
 type sliceSeq struct {
 	v reflect.Value
+	i int
 }
 
 func (s sliceSeq) First() interface{} {
@@ -17,13 +21,21 @@ func (s sliceSeq) Next() Sequence {
 	if s.v.Len() <= 1 {
 		return nil
 	}
-	return sliceSeq{v: s.v.Slice(1, s.v.Len())}
+	return sliceSeq{v: s.v.Slice(1, s.v.Len()), i: s.i + 1}
 }
 
 func (s sliceSeq) String() string {
 	return seqString(s)
 }
 
+func (s sliceSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
+func (s sliceSeq) Reduce(fn, init interface{}) interface{} {
+	return reflectSlice(s.v).Reduce(fn, init)
+}
+
 type rSlice struct {
 	v reflect.Value
 }
@@ -38,6 +50,9 @@ func (s rSlice) Reduce(fn, init interface{}) interface{} {
 	rFn := wrapReduce(fn)
 	for i := 0; i < s.v.Len(); i++ {
 		res = rFn(res, s.v.Index(i).Interface())
+		if transduce.IsReduced(res) {
+			return transduce.Unreduced(res)
+		}
 	}
 	return res
 }
@@ -46,20 +61,90 @@ func reflectSlice(v reflect.Value) rSlice {
 	return rSlice{v}
 }
 
+type rString struct {
+	v reflect.Value
+}
+
+func reflectString(v reflect.Value) rString {
+	return rString{v}
+}
+
+// Conj appends a rune, byte or string to the string returning a new
+// string of the same type. Runes are UTF-8 encoded while bytes are
+// appended as is.
+func (s rString) Conj(item interface{}) interface{} {
+	var str string
+	switch v := item.(type) {
+	case rune:
+		str = s.v.String() + string(v)
+	case byte:
+		str = s.v.String() + string([]byte{v})
+	case string:
+		str = s.v.String() + v
+	default:
+		panic(fmt.Errorf("cannot conj %T onto a string", item))
+	}
+	return reflect.ValueOf(str).Convert(s.v.Type()).Interface()
+}
+
+func (s rString) Reduce(fn, init interface{}) interface{} {
+	res := init
+	rFn := wrapReduce(fn)
+	for _, r := range s.v.String() {
+		res = rFn(res, r)
+		if transduce.IsReduced(res) {
+			return transduce.Unreduced(res)
+		}
+	}
+	return res
+}
+
 func reflectSeq(coll interface{}) Sequence {
 	v := reflect.ValueOf(coll)
 	switch v.Kind() {
 	case reflect.Slice:
 		return sliceSequence(v)
+	case reflect.Array:
+		// Arrays in an interface are not addressable so a copy is
+		// made to allow slicing.
+		arr := reflect.New(v.Type()).Elem()
+		arr.Set(v)
+		return sliceSequence(arr.Slice(0, arr.Len()))
 	case reflect.String:
 		return sliceSequence(reflect.ValueOf([]rune(coll.(string))))
 	case reflect.Map:
 		return mapSequence(v)
+	case reflect.Chan:
+		if v.Type().ChanDir()&reflect.RecvDir == 0 {
+			panic(fmt.Errorf("cannot receive from %T", coll))
+		}
+		return chanSeqNew(v)
 	default:
+		if s, ok := addressableSeq(v); ok {
+			return s
+		}
 		panic(fmt.Errorf("cannot convert %T to Seq", coll))
 	}
 }
 
+// addressableSeq handles values whose type implements Seqable or
+// Sequence only with a pointer receiver. A copy of the value is made
+// addressable and the methods are called on a pointer to the copy.
+func addressableSeq(v reflect.Value) (Sequence, bool) {
+	if !v.IsValid() || !reflect.PtrTo(v.Type()).Implements(seqableType) &&
+		!reflect.PtrTo(v.Type()).Implements(sequenceType) {
+		return nil, false
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return Seq(p.Interface()), true
+}
+
+var (
+	seqableType  = reflect.TypeOf((*Seqable)(nil)).Elem()
+	sequenceType = reflect.TypeOf((*Sequence)(nil)).Elem()
+)
+
 func reflectNative(coll interface{}) interface{} {
 	v := reflect.ValueOf(coll)
 	switch v.Kind() {
@@ -67,16 +152,24 @@ func reflectNative(coll interface{}) interface{} {
 		return reflectSlice(v)
 	case reflect.Map:
 		return reflectMap(v)
+	case reflect.String:
+		return reflectString(v)
 	default:
 		return coll
 	}
 }
 
 func sliceSequence(v reflect.Value) Sequence {
+	return sliceSequenceAt(v, 0)
+}
+
+// sliceSequenceAt returns a sequence of v that is at index i of the
+// slice it was taken from.
+func sliceSequenceAt(v reflect.Value, i int) Sequence {
 	if v.Len() == 0 {
 		return nil
 	}
-	return sliceSeq{v: v}
+	return sliceSeq{v: v, i: i}
 }
 
 // MapEntry is a key,value pair representing an item in a map
@@ -91,6 +184,12 @@ type mapEntry struct {
 	val interface{}
 }
 
+// NewMapEntry returns a MapEntry with the given key and value. This
+// allows entries to be conjoined into maps, Conj(m, NewMapEntry(k, v)).
+func NewMapEntry(k, v interface{}) MapEntry {
+	return mapEntry{key: k, val: v}
+}
+
 func (e mapEntry) Key() interface{} {
 	return e.key
 }
@@ -99,6 +198,11 @@ func (e mapEntry) Value() interface{} {
 	return e.val
 }
 
+// MarshalJSON encodes the entry as a [key, value] pair.
+func (e mapEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{e.key, e.val})
+}
+
 type rMap struct {
 	m reflect.Value
 }
@@ -124,6 +228,9 @@ func (m rMap) Reduce(fn interface{}, init interface{}) interface{} {
 			val: v.Interface(),
 		}
 		res = rFn(res, ent)
+		if transduce.IsReduced(res) {
+			return transduce.Unreduced(res)
+		}
 	}
 	return res
 }
@@ -152,6 +259,10 @@ func (s mapSeq) Next() Sequence {
 	}
 }
 
+func (s mapSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
 func mapSequence(v reflect.Value) Sequence {
 	if v.Len() == 0 {
 		return nil
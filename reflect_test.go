@@ -1,6 +1,8 @@
 package seq
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 	"testing/quick"
 )
@@ -89,3 +91,114 @@ func TestReflectMapTraversesAll(t *testing.T) {
 	}
 
 }
+
+func TestSortedMapSeqG(t *testing.T) {
+	m := map[int]string{
+		5: "five",
+		1: "one",
+		4: "four",
+		2: "two",
+		3: "three",
+	}
+	expected := []int{1, 2, 3, 4, 5}
+	got := SortedMapSeqG(m)
+	for idx, k := range expected {
+		ent := First(got).(MapEntry)
+		if ent.Key() != k || ent.Value() != m[k] {
+			t.Fatal("wanted", k, m[k], "got", ent.Key(), ent.Value(),
+				"at", idx)
+		}
+		got = Next(got)
+	}
+	if got != nil {
+		t.Fatal("unexpected value", got)
+	}
+	if SortedMapSeqG(map[int]string{}) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func TestReduceKV(t *testing.T) {
+	if err := quick.Check(func(m map[string]int) bool {
+		expected := 0
+		for _, v := range m {
+			expected += v
+		}
+		sum := func(res int, k string, v int) int {
+			return res + v
+		}
+		return ReduceKV(sum, 0, m) == expected &&
+			ReduceKV(sum, 0, Seq(m)) == expected
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReduceKVEntrySequence(t *testing.T) {
+	entries := Cons(mapEntry{"a", 1}, Cons(mapEntry{"b", 2}, nil))
+	got := ReduceKV(func(res, k, v interface{}) interface{} {
+		return res.(string) + k.(string) + fmt.Sprint(v)
+	}, "", entries)
+	if got != "a1b2" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestNewMapEntry(t *testing.T) {
+	e := NewMapEntry("a", 1)
+	if e.Key() != "a" || e.Value() != 1 {
+		t.Fatal("unexpected entry", e.Key(), e.Value())
+	}
+	m := Conj(map[string]int{"b": 2}, NewMapEntry("a", 1)).(map[string]int)
+	if !reflect.DeepEqual(m, map[string]int{"a": 1, "b": 2}) {
+		t.Fatal("unexpected map", m)
+	}
+}
+
+type ptrSeqable struct {
+	vals []int
+}
+
+func (p *ptrSeqable) Seq() Sequence {
+	return Seq(p.vals)
+}
+
+func TestSeqPointerReceiver(t *testing.T) {
+	got := Into([]int{}, Seq(ptrSeqable{vals: []int{1, 2, 3}}))
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatal("unexpected value", got)
+	}
+	if Seq(ptrSeqable{}) != nil {
+		t.Fatal("expected empty sequence")
+	}
+	if got := First(Seq(ptrSeqable{vals: []int{4}})); got != 4 {
+		t.Fatal("unexpected value", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for non-sequence struct")
+		}
+	}()
+	Seq(struct{ a int }{1})
+}
+
+func TestReflectArray(t *testing.T) {
+	arr := [3]int{1, 2, 3}
+	got := Into([]int{}, Seq(arr))
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatal("unexpected value", got)
+	}
+	if got := Reduce(func(a, b int) int { return a + b }, 0, arr); got != 6 {
+		t.Fatal("unexpected value", got)
+	}
+	if Seq([0]int{}) != nil {
+		t.Fatal("expected empty sequence")
+	}
+	if got := fmt.Sprint(Seq([2]string{"a", "b"})); got != "(a b)" {
+		t.Fatal("unexpected value", got)
+	}
+	nested := []interface{}{[2]int{1, 2}, []interface{}{[1]int{3}}}
+	if got := fmt.Sprint(FlatMap(func(x int) int { return x * 2 }, nested)); got != "(2 4 6)" {
+		t.Fatal("unexpected value", got)
+	}
+}
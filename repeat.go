@@ -34,5 +34,12 @@ func (s *repeatSeq) Next() Sequence {
 }
 
 func (s *repeatSeq) String() string {
+	if s.count == inf {
+		return infiniteSeqString(s)
+	}
 	return seqString(s)
 }
+
+func (s *repeatSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
@@ -0,0 +1,81 @@
+package seq
+
+import (
+	"sync"
+)
+
+type repeatedly struct {
+	mu       sync.Mutex
+	count    int
+	infinite bool
+	fn       interface{}
+	realized bool
+	val      interface{}
+	next     *repeatedly
+}
+
+func repeatedlyNew(count int, fn interface{}) Sequence {
+	if count <= 0 {
+		return nil
+	}
+	return &repeatedly{
+		count: count,
+		fn:    fn,
+	}
+}
+
+// Repeatedly will return a lazy sequence of n results of calling fn.
+// fn must match the signature func() oT and will be called with
+// reflection. fn is called once per realized element and the result
+// is cached.
+func Repeatedly(n int, fn interface{}) Sequence {
+	return repeatedlyNew(n, fn)
+}
+
+// RepeatedlyInfinitely will return a lazy sequence of the results of
+// calling fn forever. fn must match the signature func() oT and will be
+// called with reflection. fn is called once per realized element and
+// the result is cached.
+func RepeatedlyInfinitely(fn interface{}) Sequence {
+	return &repeatedly{infinite: true, fn: fn}
+}
+
+func (s *repeatedly) First() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.first()
+}
+
+func (s *repeatedly) first() interface{} {
+	if !s.realized {
+		s.val = apply(s.fn)
+		s.realized = true
+	}
+	return s.val
+}
+
+func (s *repeatedly) Next() Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.next == nil {
+		// Realize this element first so fn is called in order.
+		s.first()
+		switch {
+		case s.infinite:
+			s.next = &repeatedly{infinite: true, fn: s.fn}
+		case s.count > 1:
+			s.next = &repeatedly{count: s.count - 1, fn: s.fn}
+		default:
+			return nil
+		}
+	}
+	return s.next
+}
+
+func (s *repeatedly) String() string {
+	return seqString(s)
+}
+
+func (s *repeatedly) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
@@ -0,0 +1,78 @@
+package seq
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRepeatedly(t *testing.T) {
+	t.Run("Repeatedly", func(t *testing.T) {
+		calls := 0
+		counter := func() int {
+			calls++
+			return calls
+		}
+		seq := Repeatedly(5, counter)
+		expected := []int{1, 2, 3, 4, 5}
+		for idx, i := range expected {
+			f := First(seq)
+			if i != f {
+				t.Fatal("wanted", i, "got", f, "at", idx)
+			}
+			seq = Next(seq)
+		}
+		if seq != nil {
+			t.Fatal("unexpected value", seq)
+		}
+	})
+	t.Run("RepeatedlyInfinitely", func(t *testing.T) {
+		calls := 0
+		counter := func() int {
+			calls++
+			return calls
+		}
+		seq := RepeatedlyInfinitely(counter)
+		for i := 1; i <= 100; i++ {
+			f := First(seq)
+			if i != f {
+				t.Fatal("wanted", i, "got", f)
+			}
+			seq = Next(seq)
+		}
+	})
+	t.Run("cached", func(t *testing.T) {
+		calls := 0
+		counter := func() int {
+			calls++
+			return calls
+		}
+		seq := Repeatedly(3, counter)
+		first := Slice(seq)
+		second := Slice(seq)
+		if calls != 3 {
+			t.Fatal("fn called", calls, "times, expected 3")
+		}
+		if fmt.Sprint(first) != fmt.Sprint(second) {
+			t.Fatal("traversals differ", first, second)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		if Repeatedly(0, func() int { return 1 }) != nil {
+			t.Fatal("expected empty sequence")
+		}
+		for _, n := range []int{-1, -2} {
+			if Repeatedly(n, func() int { return 1 }) != nil {
+				t.Fatal("expected empty sequence for", n)
+			}
+		}
+	})
+}
+
+func ExampleRepeatedly() {
+	n := 0
+	fmt.Println(Repeatedly(5, func() int {
+		n += 2
+		return n
+	}))
+	// Output: (2 4 6 8 10)
+}
@@ -0,0 +1,38 @@
+package seq
+
+import (
+	"reflect"
+)
+
+// RunLengthEncode returns a lazy sequence of []interface{}{value, count}
+// pairs, one for each run of consecutive equal elements of coll, where
+// count is the int length of the run. Elements are compared with
+// reflect.DeepEqual. Each run is only realized when its pair is. coll
+// is any type that can be converted to a Sequence by Seq.
+func RunLengthEncode(coll interface{}) Sequence {
+	return LazySeq(func() Sequence {
+		s := Seq(coll)
+		if s == nil {
+			return nil
+		}
+		value, count := First(s), 1
+		for s = Seq(Next(s)); s != nil; s = Seq(Next(s)) {
+			if !reflect.DeepEqual(First(s), value) {
+				break
+			}
+			count++
+		}
+		return Cons([]interface{}{value, count}, RunLengthEncode(s))
+	})
+}
+
+// RunLengthDecode returns a lazy sequence that expands each
+// (value, count) pair of coll, as produced by RunLengthEncode, into
+// count repetitions of value. Each pair may be any type that can be
+// converted to a Sequence by Seq whose second element is an int. coll
+// is any type that can be converted to a Sequence by Seq.
+func RunLengthDecode(coll interface{}) Sequence {
+	return catSeqs(Map(func(pair interface{}) interface{} {
+		return Repeat(Second(pair).(int), First(pair))
+	}, coll))
+}
@@ -0,0 +1,48 @@
+package seq
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func ExampleRunLengthEncode() {
+	fmt.Println(RunLengthEncode("aaabccdd"))
+	fmt.Println(RunLengthDecode([][]interface{}{{"x", 2}, {"y", 1}}))
+	// Output: ([97 3] [98 1] [99 2] [100 2])
+	// (x x y)
+}
+
+func TestRunLengthRoundTrip(t *testing.T) {
+	if err := quick.Check(func(runs []uint8, seed int64) bool {
+		rng := rand.New(rand.NewSource(seed))
+		var in []int
+		for _, r := range runs {
+			v := rng.Intn(3)
+			for i := 0; i <= int(r%4); i++ {
+				in = append(in, v)
+			}
+		}
+		encoded := Slice(RunLengthEncode(in))
+		for i, p := range encoded {
+			pair := p.([]interface{})
+			if pair[1].(int) < 1 ||
+				(i > 0 && encoded[i-1].([]interface{})[0] == pair[0]) {
+				return false
+			}
+		}
+		return SequenceEqual(RunLengthDecode(encoded), in)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunLengthEncodeLazy(t *testing.T) {
+	got := Into([]interface{}{}, Take(2, RunLengthEncode(Cycle([]int{1, 1, 2}))))
+	exp := []interface{}{[]interface{}{1, 2}, []interface{}{2, 1}}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
@@ -6,8 +6,16 @@
 package seq
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"jsouthworth.net/go/dyn"
 	"jsouthworth.net/go/transduce"
@@ -44,6 +52,52 @@ func Next(coll interface{}) Sequence {
 	return s.Next()
 }
 
+// Rest returns the sequence without the first element. Unlike Next it
+// never returns nil, when there are no more elements it returns an
+// empty sequence whose First is nil and whose Rest is also empty. This
+// allows First and Rest to be called in a loop without checking for
+// nil. Use Seq or IsEmpty to test whether the result is empty.
+// coll is any type that can be converted to a Sequence by Seq.
+func Rest(coll interface{}) Sequence {
+	if next := Next(coll); Seq(next) != nil {
+		return next
+	}
+	return empty
+}
+
+// Second returns the second element of a sequence.
+// coll is any type that can be converted to a Sequence by Seq.
+func Second(coll interface{}) interface{} {
+	return First(Next(coll))
+}
+
+// Ffirst returns the first element of the first element of a sequence.
+// coll is any type that can be converted to a Sequence by Seq.
+func Ffirst(coll interface{}) interface{} {
+	return First(First(coll))
+}
+
+// IsEmpty returns true if the sequence has no elements.
+// coll is any type that can be converted to a Sequence by Seq.
+func IsEmpty(coll interface{}) bool {
+	return Seq(coll) == nil
+}
+
+// SequenceEqual returns true if a and b have the same length and their
+// elements at each position are equal according to reflect.DeepEqual.
+// Elements are only realized until the first difference is found. a and
+// b are any type that can be converted to a Sequence by Seq.
+func SequenceEqual(a, b interface{}) bool {
+	sa, sb := Seq(a), Seq(b)
+	for sa != nil && sb != nil {
+		if !reflect.DeepEqual(First(sa), First(sb)) {
+			return false
+		}
+		sa, sb = Seq(Next(sa)), Seq(Next(sb))
+	}
+	return sa == nil && sb == nil
+}
+
 // Conj conjoins a new element into a collection returning the
 // new collection.
 func Conj(coll interface{}, elem interface{}) interface{} {
@@ -134,6 +188,55 @@ func Map(fn interface{}, coll interface{}) Sequence {
 	return XfrmSequence(transduce.Map(fn), Seq(coll))
 }
 
+// MapMulti is a version of Map that takes any number of sequences. It
+// returns a lazy sequence of the result of applying fn to the elements
+// at the same position in each sequence, ending when the shortest
+// sequence is exhausted. fn must take as many arguments as there are
+// sequences. With a single sequence it is equivalent to Map. coll is
+// any type that can be converted to a Sequence by Seq.
+func MapMulti(fn interface{}, colls ...interface{}) Sequence {
+	if len(colls) == 1 {
+		return Map(fn, colls[0])
+	}
+	return ZipWith(fn, colls...)
+}
+
+// MapIf returns a lazy sequence that contains the result of applying
+// thenFn to each item for which pred is true and elseFn to every other
+// item. If elseFn is nil the other items are passed through unchanged.
+// pred must match the signature func(i iT) bool, thenFn and elseFn must
+// match the signature func(in iT) oT. They are called using reflection
+// unless they are the non-specialized types. coll is any type that can
+// be converted to a Sequence by Seq.
+func MapIf(pred, thenFn, elseFn interface{}, coll interface{}) Sequence {
+	predFn, thenF := wrapPred(pred), wrapMapper(thenFn)
+	elseF := func(in interface{}) interface{} {
+		return in
+	}
+	if elseFn != nil {
+		elseF = wrapMapper(elseFn)
+	}
+	return Map(func(in interface{}) interface{} {
+		if predFn(in) {
+			return thenF(in)
+		}
+		return elseF(in)
+	}, coll)
+}
+
+// Annotate returns a lazy sequence of []interface{}{item, fn(item)}
+// pairs, keeping each original item alongside the computed value. fn
+// must match the signature func(in iT) oT and will be called using
+// reflection unless it is the non-specialized type
+// func(interface{}) interface{}. coll is any type that can be converted
+// to a Sequence by Seq.
+func Annotate(fn interface{}, coll interface{}) Sequence {
+	f := wrapMapper(fn)
+	return Map(func(in interface{}) interface{} {
+		return []interface{}{in, f(in)}
+	}, coll)
+}
+
 // Replace returns a lazy sequence that contains the result of replacing
 // the values in the provided smap for the ones in the sequence. smap must
 // be one of the following types something that implements
@@ -150,6 +253,8 @@ func Replace(smap interface{}, coll interface{}) Sequence {
 // Reduce function. The reducing function 'fn' must match the signature
 // func(result rT, input iT) rT and will be called using reflection unless
 // is is the non-specialized type func(result, input interface{})interface{}.
+// If fn returns a value wrapped by Reduced the reduction stops and the
+// unwrapped value is returned.
 // coll is any type that can be converted to a Sequence by Seq.
 func Reduce(
 	fn interface{},
@@ -173,6 +278,105 @@ func Reduce(
 	}
 }
 
+// ReduceKV is a version of Reduce for sequences of MapEntry values such
+// as the sequence of a map. The reducing function 'fn' is called with
+// the key and value of each entry and must match the signature
+// func(result rT, key kT, value vT) rT. It will be called using
+// reflection unless it is the non-specialized type
+// func(result, key, value interface{}) interface{}.
+// coll is any type that can be converted to a Sequence by Seq.
+func ReduceKV(
+	fn interface{},
+	init interface{},
+	coll interface{},
+) interface{} {
+	var kvfn func(result, key, value interface{}) interface{}
+	switch f := fn.(type) {
+	case func(result, key, value interface{}) interface{}:
+		kvfn = f
+	default:
+		kvfn = func(result, key, value interface{}) interface{} {
+			return apply(f, result, key, value)
+		}
+	}
+	return Reduce(func(result, input interface{}) interface{} {
+		entry := input.(MapEntry)
+		return kvfn(result, entry.Key(), entry.Value())
+	}, init, coll)
+}
+
+// ReduceErr is a version of Reduce for reducing functions that may
+// fail. The reducing function 'fn' must match the signature
+// func(result rT, input iT) (rT, error) and will be called using
+// reflection unless it is the non-specialized type
+// func(result, input interface{}) (interface{}, error). The first
+// non-nil error stops the reduction and is returned along with the
+// result of the last successful call.
+// coll is any type that can be converted to a Sequence by Seq.
+func ReduceErr(
+	fn interface{},
+	init interface{},
+	coll interface{},
+) (interface{}, error) {
+	var efn func(result, input interface{}) (interface{}, error)
+	switch f := fn.(type) {
+	case func(result, input interface{}) (interface{}, error):
+		efn = f
+	default:
+		efn = func(result, input interface{}) (interface{}, error) {
+			out := apply(f, result, input).(dyn.Tuple)
+			err, _ := out[1].(error)
+			return out[0], err
+		}
+	}
+	var err error
+	res := Reduce(func(result, input interface{}) interface{} {
+		out, e := efn(result, input)
+		if e != nil {
+			err = e
+			return Reduced(result)
+		}
+		return out
+	}, init, coll)
+	return res, err
+}
+
+// Reduced wraps v such that when it is returned from a reducing function
+// the reduction stops and v is returned as the result.
+func Reduced(v interface{}) interface{} {
+	return transduce.Reduced(v)
+}
+
+// Reductions returns a lazy sequence of the intermediate results of
+// reducing coll with fn, starting with init. If fn returns a value
+// wrapped by Reduced the unwrapped value is the last element of the
+// sequence and the rest of coll is not consumed, which allows
+// reductions over infinite sequences to terminate. The reducing
+// function 'fn' must match the signature func(result rT, input iT) rT
+// and will be called using reflection unless it is the non-specialized
+// type func(result, input interface{}) interface{}. coll is any type
+// that can be converted to a Sequence by Seq.
+func Reductions(fn interface{}, init interface{}, coll interface{}) Sequence {
+	return reductions(wrapReduce(fn), init, coll)
+}
+
+func reductions(
+	fn func(res, in interface{}) interface{},
+	acc interface{},
+	coll interface{},
+) Sequence {
+	if transduce.IsReduced(acc) {
+		return Cons(transduce.Unreduced(acc), nil)
+	}
+	return Cons(acc, LazySeq(func() Sequence {
+		s := Seq(coll)
+		if s == nil {
+			return nil
+		}
+		return reductions(fn, fn(acc, First(s)), Next(s))
+	}))
+}
+
 func reduceSeq(
 	fn func(res, in interface{}) interface{},
 	init interface{},
@@ -180,7 +384,18 @@ func reduceSeq(
 ) interface{} {
 	ret := init
 	for s != nil {
+		if cs, ok := s.(chunkedSeq); ok {
+			ret = cs.chunkFirst().reduce(fn, ret)
+			if transduce.IsReduced(ret) {
+				return transduce.Unreduced(ret)
+			}
+			s = Seq(cs.chunkNext())
+			continue
+		}
 		ret = fn(ret, First(s))
+		if transduce.IsReduced(ret) {
+			return transduce.Unreduced(ret)
+		}
 		s = Seq(Next(s))
 	}
 	return ret
@@ -210,7 +425,7 @@ func RangeBetween(start, end int) Sequence {
 // Range returns a lazy sequence that will be the integers
 // [start, start+step, ..., end)
 func Range(start, end, step int) Sequence {
-	return rangeNew(start, end, step)
+	return rangeNew(start, end, step, 0)
 }
 
 // Repeat will return a lazy sequence that repeats x, n times.
@@ -229,6 +444,28 @@ func Iterate(fn interface{}, x interface{}) Sequence {
 	return iterateNew(fn, x)
 }
 
+// IterateRetry is like Iterate but when fn panics while computing the
+// next element it is retried up to attempts times, sleeping for backoff
+// between each try. If every retry fails the final panic propagates to
+// the caller.
+func IterateRetry(
+	fn interface{},
+	x interface{},
+	attempts int,
+	backoff time.Duration,
+) Sequence {
+	return iterateNew(retrying(fn, attempts, backoff), x)
+}
+
+// Recurrence will return a lazy sequence of the seeds followed by the
+// result of calling fn on the previous len(seeds) elements of the
+// sequence. fn must match the signature func(x0 T, ..., xk T) T and is
+// called with reflection. Each element is computed once and cached.
+// For example, Recurrence(add, 0, 1) yields the fibonacci numbers.
+func Recurrence(fn interface{}, seeds ...interface{}) Sequence {
+	return recurrenceNew(fn, seeds...)
+}
+
 // Take will return a lazy but finite sequence consisting of the first
 // n elements of the passed in sequence. coll is any type that can be
 // converted to a Sequence by Seq.
@@ -290,6 +527,95 @@ func Interleave(colls ...interface{}) Sequence {
 	})
 }
 
+// InterleaveAll returns a lazy sequence of the first element of each
+// passed in sequence followed by the second, followed by the third, and
+// so on. Unlike Interleave it does not stop when the shortest sequence
+// is exhausted, exhausted sequences are skipped and the remaining
+// elements of the longer sequences are still returned. It is the same
+// as StableMerge. coll is any type that can be converted to a Sequence
+// by Seq.
+func InterleaveAll(colls ...interface{}) Sequence {
+	return StableMerge(colls...)
+}
+
+// StableMerge returns a lazy sequence that merges the passed in
+// sequences fairly. Each round yields the next element of every
+// sequence that is not yet exhausted, in the order the sequences were
+// passed. Sequences that run out are skipped in later rounds while the
+// others continue, so the elements of each sequence always appear in
+// their original relative order. coll is any type that can be converted
+// to a Sequence by Seq.
+func StableMerge(colls ...interface{}) Sequence {
+	return LazySeq(func() Sequence {
+		seqs := make([]Sequence, 0, len(colls))
+		for _, coll := range colls {
+			if s := Seq(coll); s != nil {
+				seqs = append(seqs, s)
+			}
+		}
+		if len(seqs) == 0 {
+			return nil
+		}
+		rests := make([]interface{}, len(seqs))
+		for i, s := range seqs {
+			rests[i] = Next(s)
+		}
+		out := StableMerge(rests...)
+		for i := len(seqs) - 1; i >= 0; i-- {
+			out = Cons(First(seqs[i]), out)
+		}
+		return out
+	})
+}
+
+// Zip returns a lazy sequence of []interface{} tuples where the ith
+// tuple contains the ith element of each passed in sequence. The
+// sequence ends when the shortest passed in sequence is exhausted.
+// coll is any type that can be converted to a Sequence by Seq.
+func Zip(colls ...interface{}) Sequence {
+	return LazySeq(func() Sequence {
+		if len(colls) == 0 {
+			return nil
+		}
+		tuple := make([]interface{}, len(colls))
+		rests := make([]interface{}, len(colls))
+		for i, coll := range colls {
+			s := Seq(coll)
+			if s == nil {
+				return nil
+			}
+			tuple[i] = First(s)
+			rests[i] = Next(s)
+		}
+		return Cons(tuple, Zip(rests...))
+	})
+}
+
+// ZipWith returns a lazy sequence of the result of applying fn to the
+// ith element of each of the passed in sequences. fn must take as many
+// arguments as there are sequences, func(a aT, b bT, ...) oT, and will
+// be called using reflection. The sequence ends when the shortest
+// passed in sequence is exhausted. coll is any type that can be
+// converted to a Sequence by Seq.
+func ZipWith(fn interface{}, colls ...interface{}) Sequence {
+	return Map(func(tuple []interface{}) interface{} {
+		return apply(fn, tuple...)
+	}, Zip(colls...))
+}
+
+// Combine returns a lazy sequence of the result of applying fn to the
+// elements at the same position in a and b. It ends when the shorter of
+// a and b is exhausted. fn must match the signature func(a aT, b bT) oT
+// and will be called using reflection unless it is the non-specialized
+// type func(interface{}, interface{}) interface{}. a and b are any type
+// that can be converted to a Sequence by Seq.
+func Combine(fn interface{}, a, b interface{}) Sequence {
+	f := wrapReduce(fn)
+	return Map(func(pair []interface{}) interface{} {
+		return f(pair[0], pair[1])
+	}, Zip(a, b))
+}
+
 // Interpose returns a lazy sequence of  the elements of the passed in sequence
 // seperated by the passed in seperator. coll is any type that can be converted
 // to a Sequence by Seq.
@@ -297,6 +623,36 @@ func Interpose(seperator interface{}, coll interface{}) Sequence {
 	return XfrmSequence(transduce.Interpose(seperator), Seq(coll))
 }
 
+// InterposeBy returns a lazy sequence of the elements of coll separated
+// by the result of calling sepFn with each adjacent pair of elements.
+// sepFn must match the signature func(prev pT, next nT) sT and will be
+// called using reflection unless it is the non-specialized type
+// func(prev, next interface{}) interface{}. coll is any type that can
+// be converted to a Sequence by Seq.
+func InterposeBy(sepFn interface{}, coll interface{}) Sequence {
+	return XfrmSequence(interposeBy(wrapReduce(sepFn)), Seq(coll))
+}
+
+func interposeBy(sepFn func(prev, next interface{}) interface{}) transduce.Transducer {
+	return func(rf transduce.ReducerFn) transduce.ReducerFn {
+		var prev interface{}
+		started := false
+		return transduce.Reducing(
+			func(result, input interface{}) interface{} {
+				if started {
+					result = rf.Step(result, sepFn(prev, input))
+					if transduce.IsReduced(result) {
+						return result
+					}
+				}
+				started = true
+				prev = input
+				return rf.Step(result, input)
+			},
+		)(rf)
+	}
+}
+
 // Filter returns a lazy sequence that will contain the elements of the
 // passed in sequence for which pred is true. pred must match the signature
 // func(i iT) bool and will be called with reflection unless it is the
@@ -324,6 +680,16 @@ func TakeWhile(pred interface{}, coll interface{}) Sequence {
 	return XfrmSequence(transduce.TakeWhile(pred), Seq(coll))
 }
 
+// TakeUntilValue returns a lazy sequence of the items from the passed
+// in sequence up to, but not including, the first item equal to
+// sentinel. Items are compared with reflect.DeepEqual. coll is any type
+// that can be converted to a Sequence by Seq.
+func TakeUntilValue(sentinel interface{}, coll interface{}) Sequence {
+	return TakeWhile(func(x interface{}) bool {
+		return !reflect.DeepEqual(x, sentinel)
+	}, coll)
+}
+
 // DropWhile returns a lazy sequence of the items from the passed in sequence
 // starting with the first element that for which pred returns false.
 // pred must match the signature func(i iT) bool and will be called with
@@ -351,12 +717,190 @@ func KeepIndexed(f interface{}, coll interface{}) Sequence {
 	return XfrmSequence(transduce.KeepIndexed(f), Seq(coll))
 }
 
+// RandomSample returns a lazy sequence that contains each element of
+// coll with probability prob. prob must be in the range [0,1]. coll is
+// any type that can be converted to a Sequence by Seq.
+func RandomSample(prob float64, coll interface{}) Sequence {
+	checkProbability(prob)
+	return XfrmSequence(transduce.RandomSample(prob), Seq(coll))
+}
+
+// RandomSampleSeeded is RandomSample using rng as the source of
+// randomness, which makes the selection reproducible for a given seed.
+// rng must not be used concurrently while the sequence is realized.
+func RandomSampleSeeded(prob float64, rng *rand.Rand, coll interface{}) Sequence {
+	checkProbability(prob)
+	return XfrmSequence(transduce.Filter(func(interface{}) bool {
+		return rng.Float64() < prob
+	}), Seq(coll))
+}
+
+func checkProbability(prob float64) {
+	if prob < 0 || prob > 1 {
+		panic(fmt.Errorf("probability %v is not in the range [0,1]", prob))
+	}
+}
+
 // Dedupe returns a lazy sequence with any duplicates removed.
 // coll is any type that can be converted to a Sequence by Seq.
 func Dedupe(coll interface{}) Sequence {
 	return XfrmSequence(transduce.Dedupe(), Seq(coll))
 }
 
+// DedupeTap is Dedupe that calls onDrop with each consecutive duplicate
+// it removes. This allows the removed elements to be observed without
+// traversing the sequence a second time. Only the previous element is
+// retained. Elements are compared with reflect.DeepEqual so they need
+// not be comparable and, as with Dedupe, leading nil elements are
+// removed. coll is any type that can be converted to a Sequence by Seq.
+func DedupeTap(onDrop func(interface{}), coll interface{}) Sequence {
+	return XfrmSequence(dedupe(reflect.DeepEqual, onDrop), Seq(coll))
+}
+
+// DedupeBy returns a lazy sequence with consecutive elements that have
+// the same key as the previous element removed. Keys are the result of
+// keyFn and are compared with reflect.DeepEqual. keyFn must match the
+// signature func(i iT) oT and will be called using reflection unless it
+// is the non-specialized type func(interface{}) interface{}. coll is
+// any type that can be converted to a Sequence by Seq.
+func DedupeBy(keyFn interface{}, coll interface{}) Sequence {
+	return XfrmSequence(dedupeBy(wrapMapper(keyFn)), Seq(coll))
+}
+
+func dedupeBy(keyFn func(interface{}) interface{}) transduce.Transducer {
+	return func(rf transduce.ReducerFn) transduce.ReducerFn {
+		var prior interface{}
+		started := false
+		return transduce.Reducing(
+			func(result, input interface{}) interface{} {
+				key := keyFn(input)
+				if started && reflect.DeepEqual(key, prior) {
+					return result
+				}
+				started = true
+				prior = key
+				return rf.Step(result, input)
+			},
+		)(rf)
+	}
+}
+
+// RunningExtent returns a lazy sequence of the smallest and largest
+// elements seen up to and including each position of the passed in
+// sequence. Each element of the result is a []interface{}{min, max}
+// pair as ordered by less. coll is any type that can be converted
+// to a Sequence by Seq.
+func RunningExtent(less func(a, b interface{}) bool, coll interface{}) Sequence {
+	return XfrmSequence(runningExtent(less), Seq(coll))
+}
+
+func runningExtent(less func(a, b interface{}) bool) transduce.Transducer {
+	return func(rf transduce.ReducerFn) transduce.ReducerFn {
+		var min, max interface{}
+		started := false
+		return transduce.Reducing(
+			func(result, input interface{}) interface{} {
+				switch {
+				case !started:
+					min, max = input, input
+					started = true
+				case less(input, min):
+					min = input
+				case less(max, input):
+					max = input
+				}
+				return rf.Step(result, []interface{}{min, max})
+			},
+		)(rf)
+	}
+}
+
+// DedupeSum returns a lazy sequence that collapses each run of
+// consecutive elements with the same key into a single
+// []interface{}{key, sum} pair, where sum is the total of the values of the
+// elements in the run. Keys are the result of keyFn and are compared
+// with reflect.DeepEqual. Values are the result of valFn and must all be
+// the same numeric type. keyFn and valFn must match the signature
+// func(i iT) oT and will be called using reflection unless they are the
+// non-specialized type func(interface{}) interface{}. coll is any type
+// that can be converted to a Sequence by Seq.
+func DedupeSum(keyFn, valFn interface{}, coll interface{}) Sequence {
+	return XfrmSequence(dedupeSum(wrapMapper(keyFn), wrapMapper(valFn)),
+		Seq(coll))
+}
+
+func dedupeSum(keyFn, valFn func(interface{}) interface{}) transduce.Transducer {
+	return func(rf transduce.ReducerFn) transduce.ReducerFn {
+		var key, sum interface{}
+		started := false
+		return transduce.Reducer(
+			func() interface{} {
+				return rf.Init()
+			},
+			func(result interface{}) interface{} {
+				if started {
+					started = false
+					result = transduce.Unreduced(
+						rf.Step(result, []interface{}{key, sum}))
+				}
+				return rf.Result(result)
+			},
+			func(result, input interface{}) interface{} {
+				k, v := keyFn(input), valFn(input)
+				if started && reflect.DeepEqual(k, key) {
+					sum = addNumbers(sum, v)
+					return result
+				}
+				if started {
+					result = rf.Step(result, []interface{}{key, sum})
+				}
+				key, sum, started = k, v, true
+				return result
+			},
+		)
+	}
+}
+
+// addNumbers adds two numbers of the same type.
+func addNumbers(a, b interface{}) interface{} {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() || av.Type() != bv.Type() {
+		panic(fmt.Errorf("cannot add %T and %T", a, b))
+	}
+	out := reflect.New(av.Type()).Elem()
+	switch av.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		out.SetInt(av.Int() + bv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		out.SetUint(av.Uint() + bv.Uint())
+	case reflect.Float32, reflect.Float64:
+		out.SetFloat(av.Float() + bv.Float())
+	default:
+		panic(fmt.Errorf("cannot add non-numeric type %T", a))
+	}
+	return out.Interface()
+}
+
+// Rotate returns a sequence of the elements of coll shifted cyclically
+// to the left by n places, or to the right if n is negative. Shifts
+// larger than the length of coll wrap around. Rotate realizes coll to
+// find its length so it must be finite. coll is any type that can be
+// converted to a Sequence by Seq.
+func Rotate(n int, coll interface{}) Sequence {
+	elems := Slice(coll)
+	if len(elems) == 0 {
+		return nil
+	}
+	n %= len(elems)
+	if n < 0 {
+		n += len(elems)
+	}
+	out := append(elems[n:len(elems):len(elems)], elems[:n]...)
+	return sliceSequence(reflect.ValueOf(out))
+}
+
 // SplitAt returns a sequence containing two sequences corresponding
 // to the split index. coll is any type that can be converted to a
 // Sequence by Seq.
@@ -366,6 +910,40 @@ func SplitAt(index int, coll interface{}) Sequence {
 		Cons(Drop(index, s), nil))
 }
 
+// SplitAtIndependent returns the first index elements of the passed in
+// sequence and the remaining elements as two sequences that can be
+// consumed independently. The first time either is realized the prefix
+// is realized into a cache and the remainder is chained after it so
+// consuming the second sequence never walks the prefix again. The
+// trade-off is that the whole prefix is held in memory until both
+// sequences are released. coll is any type that can be converted to a
+// Sequence by Seq.
+func SplitAtIndependent(index int, coll interface{}) (Sequence, Sequence) {
+	var (
+		once   sync.Once
+		prefix []interface{}
+		rest   Sequence
+	)
+	split := func() {
+		once.Do(func() {
+			rest = Seq(coll)
+			for i := 0; i < index && rest != nil; i++ {
+				prefix = append(prefix, First(rest))
+				rest = Seq(Next(rest))
+			}
+		})
+	}
+	head := LazySeq(func() Sequence {
+		split()
+		return Seq(prefix)
+	})
+	tail := LazySeq(func() Sequence {
+		split()
+		return rest
+	})
+	return head, tail
+}
+
 // SplitWith returns a sequence containing two sequences corresponding
 // to predicate.
 // pred must match the signature func(i iT) bool and will be called with
@@ -377,6 +955,28 @@ func SplitWith(pred interface{}, coll interface{}) Sequence {
 		Cons(DropWhile(pred, s), nil))
 }
 
+// Separate returns a sequence containing two lazy sequences, the
+// elements of coll for which pred is true followed by those for which
+// it is false. Unlike SplitWith every element is considered, not just
+// the leading ones. pred is called once per element and coll is
+// traversed once however the two sequences are consumed. pred must
+// match the signature func(i iT) bool and will be called with
+// reflection unless it is the non-specialized type
+// func(interface{}) bool. coll is any type that can be converted to a
+// Sequence by Seq.
+func Separate(pred interface{}, coll interface{}) Sequence {
+	predFn := wrapPred(pred)
+	tagged := Map(func(in interface{}) interface{} {
+		return []interface{}{in, predFn(in)}
+	}, coll)
+	side := func(want bool) Sequence {
+		return Map(First, Filter(func(t interface{}) bool {
+			return t.([]interface{})[1] == want
+		}, tagged))
+	}
+	return Cons(side(true), Cons(side(false), nil))
+}
+
 // Every will iterate over every element of the sequence and return if
 // the predicate hold for every element. pred must match the signature
 // func(i iT) bool and will be called with reflection unless it is the
@@ -408,6 +1008,42 @@ func Some(pred interface{}, coll interface{}) bool {
 	return wrapPred(pred)(First(s)) || Some(pred, Next(s))
 }
 
+// Contains returns true if any element of the sequence is equal to
+// target. Elements are compared with reflect.DeepEqual. It stops at the
+// first match so it is safe to use on an infinite sequence that
+// contains target. coll is any type that can be converted to a Sequence
+// by Seq.
+func Contains(coll interface{}, target interface{}) bool {
+	return ContainsOpts(coll, target)
+}
+
+// IndexOf returns the index of the first element of the sequence
+// equal to target or -1 if there is none. Elements are compared with
+// reflect.DeepEqual so non-comparable elements are safe. It stops at the
+// first match. coll is any type that can be converted to a Sequence by
+// Seq.
+func IndexOf(coll interface{}, target interface{}) int {
+	return IndexOfOpts(coll, target)
+}
+
+// PositionBy returns the index of the first element of the sequence for
+// which pred is true or -1 if there is none. It stops at the first
+// match. pred must match the signature func(i iT) bool and will be
+// called with reflection unless it is the non-specialized type
+// func(interface{}) bool. coll is any type that can be converted to a
+// Sequence by Seq.
+func PositionBy(pred interface{}, coll interface{}) int {
+	predFn := wrapPred(pred)
+	idx := 0
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		if predFn(First(s)) {
+			return idx
+		}
+		idx++
+	}
+	return -1
+}
+
 func wrapPred(pred interface{}) func(interface{}) bool {
 	switch fn := pred.(type) {
 	case func(interface{}) bool:
@@ -419,6 +1055,17 @@ func wrapPred(pred interface{}) func(interface{}) bool {
 	}
 }
 
+func wrapMapper(f interface{}) func(interface{}) interface{} {
+	switch fn := f.(type) {
+	case func(interface{}) interface{}:
+		return fn
+	default:
+		return func(in interface{}) interface{} {
+			return apply(f, in)
+		}
+	}
+}
+
 // NotEvery is the inverse of Every.
 // pred must match the signature func(i iT) bool and will be called with
 // reflection unless it is the non-specialized type func(interface{}) bool.
@@ -448,15 +1095,117 @@ func DoAll(coll interface{}) Sequence {
 func DoRun(coll interface{}) {
 	s := Seq(coll)
 	for s != nil {
+		if cs, ok := s.(chunkedSeq); ok {
+			s = Seq(cs.chunkNext())
+			continue
+		}
 		s = Seq(Next(s))
 	}
 }
 
+// Tap returns a lazy sequence of the elements of coll unchanged, calling
+// fn with each element for its side effects as the element is realized.
+// This allows the values flowing through a pipeline to be observed.
+// fn must match the signature func(i iT) and will be called using
+// reflection unless it is the non-specialized type func(interface{}).
+// coll is any type that can be converted to a Sequence by Seq.
+func Tap(fn interface{}, coll interface{}) Sequence {
+	f, ok := fn.(func(interface{}))
+	if !ok {
+		f = func(in interface{}) {
+			apply(fn, in)
+		}
+	}
+	return Map(func(in interface{}) interface{} {
+		f(in)
+		return in
+	}, coll)
+}
+
+// Doseq calls fn with each element of coll in order for its side
+// effects, ignoring any results. The whole sequence is realized before
+// Doseq returns. fn must match the signature func(i iT) and will be
+// called using reflection unless it is the non-specialized type
+// func(interface{}). coll is any type that can be converted to a
+// Sequence by Seq.
+func Doseq(fn interface{}, coll interface{}) {
+	f, ok := fn.(func(interface{}))
+	if !ok {
+		f = func(in interface{}) {
+			apply(fn, in)
+		}
+	}
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		f(First(s))
+	}
+}
+
+// DoseqIndexed is like Doseq but fn is also passed the index of each
+// element. fn must match the signature func(idx int, i iT) and will be
+// called using reflection unless it is the non-specialized type
+// func(int, interface{}). coll is any type that can be converted to a
+// Sequence by Seq.
+func DoseqIndexed(fn interface{}, coll interface{}) {
+	f, ok := fn.(func(int, interface{}))
+	if !ok {
+		f = func(idx int, in interface{}) {
+			apply(fn, idx, in)
+		}
+	}
+	i := 0
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		f(i, First(s))
+		i++
+	}
+}
+
+// ForEach calls fn with each element of coll in order until fn returns
+// false or coll is exhausted. Elements after the one for which fn
+// returns false are not realized, so ForEach may be used on infinite
+// sequences. fn must match the signature func(i iT) bool and will be
+// called with reflection unless it is the non-specialized type
+// func(interface{}) bool. coll is any type that can be converted to a
+// Sequence by Seq.
+func ForEach(fn interface{}, coll interface{}) {
+	f := wrapPred(fn)
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		if !f(First(s)) {
+			return
+		}
+	}
+}
+
+// DoAllContext is like DoAll but stops realizing elements and returns
+// ctx.Err() once ctx is done. coll is any type that can be converted to
+// a Sequence by Seq.
+func DoAllContext(ctx context.Context, coll interface{}) (Sequence, error) {
+	s := Seq(coll)
+	return s, DoRunContext(ctx, s)
+}
+
+// DoRunContext is like DoRun but checks ctx before realizing each
+// element and returns ctx.Err() once ctx is done. This allows the
+// realization of large or infinite sequences to be cancelled. coll is
+// any type that can be converted to a Sequence by Seq.
+func DoRunContext(ctx context.Context, coll interface{}) error {
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Seq will convert a type to a sequence. If the type is Sequable it will
 // run Seq(), if it is already a sequence it will return the sequence,
 // otherwise it will attempt to build a sequence using reflection.
-// Currently it supports automatic conversion of both
-// arbitray go slices ([]T) and strings.
+// Currently it supports automatic conversion of arbitray go slices
+// ([]T), arrays, strings, maps and channels. Converting a channel
+// receives its first value, blocking until one is available, and the
+// rest of the values as the sequence is realized. A channel can only be
+// drained once so each call to Seq on the same channel continues where
+// the previous ones left off, but the values received by a sequence are
+// cached so that sequence may be traversed more than once.
 func Seq(coll interface{}) Sequence {
 	if coll == nil {
 		return nil
@@ -479,10 +1228,130 @@ func Slice(coll interface{}) []interface{} {
 	}, []interface{}{}, coll).([]interface{})
 }
 
+// SliceOf realizes coll into a slice whose element type is the type of
+// the sample value elemType, for example SliceOf(coll, 0) returns an
+// []int. The result may be asserted to the slice type. It panics if an
+// element is not assignable to the element type. coll is any type that
+// can be converted to a Sequence by Seq.
+func SliceOf(coll interface{}, elemType interface{}) interface{} {
+	typ := reflect.TypeOf(elemType)
+	if typ == nil {
+		panic(fmt.Errorf("seq.SliceOf: elemType must not be nil"))
+	}
+	out := reflect.MakeSlice(reflect.SliceOf(typ), 0, 0)
+	out = Reduce(func(out reflect.Value, elem interface{}) reflect.Value {
+		v := reflect.ValueOf(elem)
+		switch {
+		case !v.IsValid() && isNillable(typ):
+			v = reflect.Zero(typ)
+		case !v.IsValid() || !v.Type().AssignableTo(typ):
+			panic(fmt.Errorf("seq.SliceOf: element of type %T is not %v",
+				elem, typ))
+		}
+		return reflect.Append(out, v)
+	}, out, coll).(reflect.Value)
+	return out.Interface()
+}
+
+func isNillable(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Ptr, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// Keys returns a lazy sequence of the keys of a sequence of MapEntry
+// values, such as the sequence of a map. coll is any type that can be
+// converted to a Sequence by Seq.
+func Keys(coll interface{}) Sequence {
+	return Map(func(e interface{}) interface{} {
+		return e.(MapEntry).Key()
+	}, coll)
+}
+
+// Vals returns a lazy sequence of the values of a sequence of MapEntry
+// values, such as the sequence of a map. coll is any type that can be
+// converted to a Sequence by Seq.
+func Vals(coll interface{}) Sequence {
+	return Map(func(e interface{}) interface{} {
+		return e.(MapEntry).Value()
+	}, coll)
+}
+
+// ToMap realizes coll into a map. The elements of coll must either be
+// MapEntry values, such as those of the sequence of a map, or two
+// element []interface{} key, value pairs. Later entries overwrite
+// earlier ones with the same key. coll is any type that can be
+// converted to a Sequence by Seq.
+func ToMap(coll interface{}) map[interface{}]interface{} {
+	return Reduce(func(m map[interface{}]interface{}, elem interface{}) map[interface{}]interface{} {
+		switch e := elem.(type) {
+		case MapEntry:
+			m[e.Key()] = e.Value()
+		case []interface{}:
+			if len(e) != 2 {
+				panic(fmt.Errorf("seq.ToMap: pair has %d elements, want 2",
+					len(e)))
+			}
+			m[e[0]] = e[1]
+		default:
+			panic(fmt.Errorf("seq.ToMap: element of type %T is not a MapEntry or pair",
+				elem))
+		}
+		return m
+	}, map[interface{}]interface{}{}, coll).(map[interface{}]interface{})
+}
+
+// ToSet realizes coll into a set of its distinct elements. The elements
+// must be usable as map keys, including any values held in interface
+// fields, otherwise ToSet panics. coll must be
+// finite and is any type that can be converted to a Sequence by Seq.
+func ToSet(coll interface{}) map[interface{}]struct{} {
+	return Reduce(func(set map[interface{}]struct{}, elem interface{}) map[interface{}]struct{} {
+		if !isHashable(elem) {
+			panic(fmt.Errorf("seq.ToSet: element of type %T is not hashable",
+				elem))
+		}
+		set[elem] = struct{}{}
+		return set
+	}, map[interface{}]struct{}{}, coll).(map[interface{}]struct{})
+}
+
+// Count returns the number of elements in coll. Lists built with Cons
+// know their length and are counted in constant time, otherwise coll
+// is traversed up to the first such list. coll must be finite and is
+// any type that can be converted to a Sequence by Seq.
+func Count(coll interface{}) int {
+	n := 0
+	for s := Seq(coll); s != nil; s = Seq(s.Next()) {
+		if c, ok := s.(*cons); ok && c.count > 0 {
+			return n + c.count
+		}
+		n++
+	}
+	return n
+}
+
+// CountBy returns the number of elements of coll for each key returned
+// by keyFn. Keys must be comparable. keyFn must match the signature
+// func(i iT) kT and will be called using reflection unless it is the
+// non-specialized type func(interface{}) interface{}. coll must be
+// finite and is any type that can be converted to a Sequence by Seq.
+func CountBy(keyFn interface{}, coll interface{}) map[interface{}]int {
+	key := wrapMapper(keyFn)
+	return Reduce(func(counts map[interface{}]int, elem interface{}) map[interface{}]int {
+		counts[key(elem)]++
+		return counts
+	}, map[interface{}]int{}, coll).(map[interface{}]int)
+}
+
 // Concat returns a lazy sequence that is the concatenation of the provided
 // sequences. coll is any type that can be converted to a Sequence by Seq.
 func Concat(colls ...interface{}) Sequence {
-	return XfrmSequence(transduce.Cat(Reduce), Seq(colls))
+	return catSeqs(colls)
 }
 
 // Mapcat returns a lazy sequence that is the concatenation of the
@@ -494,6 +1363,52 @@ func Mapcat(f interface{}, colls ...interface{}) Sequence {
 	return XfrmSequence(transduce.Mapcat(Reduce, f), Seq(colls))
 }
 
+// FlatMap returns a lazy sequence that is the result of applying f to
+// each leaf of the nested sequence coll. Any element that is a
+// Sequence, Seqable, slice or array is flattened recursively, all other
+// elements, including strings, are leaves. This walks coll once without
+// building an intermediate flattened sequence.
+// f must be of the form func(in iT) oT and will be called with
+// reflection unless it is the non-specialized func(interface{})interface{}.
+// coll is any type that can be converted to a Sequence by Seq.
+func FlatMap(f interface{}, coll interface{}) Sequence {
+	return flatMap(wrapMapper(f), coll)
+}
+
+func flatMap(f func(interface{}) interface{}, coll interface{}) Sequence {
+	return LazySeq(func() Sequence {
+		s := Seq(coll)
+		if s == nil {
+			return nil
+		}
+		x := First(s)
+		if isSequential(x) {
+			return LazyCat(
+				func() Sequence { return flatMap(f, x) },
+				func() Sequence { return flatMap(f, Next(s)) },
+			)
+		}
+		return Cons(f(x), flatMap(f, Next(s)))
+	})
+}
+
+// isSequential reports whether x is an ordered collection that should
+// be flattened.
+func isSequential(x interface{}) bool {
+	switch x.(type) {
+	case nil:
+		return false
+	case Sequence, Seqable:
+		return true
+	}
+	switch reflect.TypeOf(x).Kind() {
+	case reflect.Slice, reflect.Array:
+		return true
+	default:
+		return false
+	}
+}
+
 // PartitionBy returns a lazy sequence that consists of partitions of
 // the provided sequence. The partitions are determined by f which is
 // any function of type func(i iT) oT. When f returns a different value
@@ -531,23 +1446,106 @@ func ConvertToString(coll Sequence) string {
 	return seqString(coll)
 }
 
-func seqString(coll Sequence) string {
+// marshalSeqJSON encodes the sequence as a JSON array by realizing
+// each of its elements. Nested sequences are encoded as nested arrays.
+// It does not terminate for infinite sequences such as the result of
+// RepeateInfinitely.
+func marshalSeqJSON(coll Sequence) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		var out []byte
+		var err error
+		switch v := First(s).(type) {
+		case json.Marshaler:
+			out, err = v.MarshalJSON()
+		case Sequence:
+			out, err = marshalSeqJSON(v)
+		default:
+			out, err = json.Marshal(v)
+		}
+		if err != nil {
+			return nil, err
+		}
+		b.Write(out)
+	}
+	b.WriteByte(']')
+	return b.Bytes(), nil
+}
+
+// FormatOptions controls how Format prints a sequence.
+type FormatOptions struct {
+	// Open and Close surround the elements.
+	Open, Close string
+	// Sep is printed between adjacent elements.
+	Sep string
+	// MaxElements limits the number of elements printed when it is
+	// greater than zero. If the sequence is longer the printed
+	// elements are followed by "..." which allows infinite sequences
+	// to be printed.
+	MaxElements int
+}
+
+var defaultFormat = FormatOptions{Open: "(", Close: ")", Sep: " "}
+
+// Format converts a sequence to a string as configured by opts. Each
+// element is printed with the %v verb. ConvertToString is Format with
+// parentheses and a single space separator.
+func Format(coll Sequence, opts FormatOptions) string {
 	var b strings.Builder
+	b.WriteString(opts.Open)
 	coll = Seq(coll)
-	if coll == nil {
-		return "()"
-	}
-	fmt.Fprint(&b, "(")
-	for coll != nil {
-		first := First(coll)
-		next := Seq(Next(coll))
-		if next == nil {
-			fmt.Fprintf(&b, "%v", first)
-		} else {
-			fmt.Fprintf(&b, "%v ", first)
+	for i := 0; coll != nil; i++ {
+		if i > 0 {
+			b.WriteString(opts.Sep)
 		}
-		coll = next
+		if opts.MaxElements > 0 && i == opts.MaxElements {
+			b.WriteString("...")
+			break
+		}
+		fmt.Fprintf(&b, "%v", First(coll))
+		coll = Seq(Next(coll))
 	}
-	fmt.Fprint(&b, ")")
+	b.WriteString(opts.Close)
 	return b.String()
 }
+
+// printLimit is the maximum number of elements printed by the String
+// methods of the sequences in this package, zero means no limit.
+var printLimit int64
+
+// defaultInfinitePrintLimit is the number of elements printed for
+// sequences known to be infinite when no print limit is set.
+const defaultInfinitePrintLimit = 10
+
+// SetPrintLimit sets the maximum number of elements printed by the
+// String methods of the sequences in this package, and so by
+// ConvertToString and the fmt package. Longer sequences are printed
+// with a trailing "...". A limit of zero, the default, prints every
+// element, except for infinite repeats and cycles which are always
+// truncated. It is safe to call concurrently with printing.
+func SetPrintLimit(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt64(&printLimit, int64(n))
+}
+
+func seqString(coll Sequence) string {
+	opts := defaultFormat
+	opts.MaxElements = int(atomic.LoadInt64(&printLimit))
+	return Format(coll, opts)
+}
+
+// infiniteSeqString is seqString for sequences known to be infinite.
+func infiniteSeqString(coll Sequence) string {
+	opts := defaultFormat
+	opts.MaxElements = int(atomic.LoadInt64(&printLimit))
+	if opts.MaxElements == 0 {
+		opts.MaxElements = defaultInfinitePrintLimit
+	}
+	return Format(coll, opts)
+}
@@ -1,11 +1,18 @@
 package seq
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"testing/quick"
+	"time"
 
 	"jsouthworth.net/go/transduce"
 )
@@ -18,6 +25,78 @@ func TestFirst(t *testing.T) {
 	}
 }
 
+func TestRest(t *testing.T) {
+	single := Seq([]int{1})
+	if Next(single) != nil {
+		t.Fatal("expected Next to be nil")
+	}
+	rest := Rest(single)
+	if rest == nil || !IsEmpty(rest) || First(rest) != nil {
+		t.Fatal("expected Rest to be a non-nil empty sequence", rest)
+	}
+	if Rest(rest) != rest || Rest(nil) != rest {
+		t.Fatal("expected the canonical empty sequence")
+	}
+	if fmt.Sprint(rest) != "()" {
+		t.Fatal("unexpected string", rest)
+	}
+	var got []interface{}
+	for s := Rest(Seq([]int{0, 1, 2})); !IsEmpty(s); s = Rest(s) {
+		got = append(got, First(s))
+	}
+	if !reflect.DeepEqual(got, []interface{}{1, 2}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestSecond(t *testing.T) {
+	if err := quick.Check(func(is []int) bool {
+		if len(is) < 2 {
+			return Second(is) == nil
+		}
+		return Second(is) == is[1]
+	}, nil); err != nil {
+		t.Error(err)
+	}
+	if Second(nil) != nil {
+		t.Fatal("unexpected value", Second(nil))
+	}
+}
+
+func TestFfirst(t *testing.T) {
+	if got := Ffirst([][]int{{1, 2}, {3}}); got != 1 {
+		t.Fatal("unexpected value", got)
+	}
+	if got := Ffirst([][]int{{}, {3}}); got != nil {
+		t.Fatal("unexpected value", got)
+	}
+	if got := Ffirst(nil); got != nil {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestIsEmpty(t *testing.T) {
+	tests := []struct {
+		coll     interface{}
+		expected bool
+	}{
+		{nil, true},
+		{[]int{}, true},
+		{"", true},
+		{RangeUntil(0), true},
+		{Filter(func(x int) bool { return x > 10 }, RangeUntil(10)), true},
+		{[]int{1}, false},
+		{RangeUntil(10), false},
+		{RepeateInfinitely(1), false},
+	}
+	for _, test := range tests {
+		if got := IsEmpty(test.coll); got != test.expected {
+			t.Fatal("IsEmpty(", test.coll, ") wanted", test.expected,
+				"got", got)
+		}
+	}
+}
+
 func TestConjSlice(t *testing.T) {
 	if err := quick.Check(func(is []int, other int) bool {
 		new := Conj(is, other).([]int)
@@ -56,6 +135,118 @@ func TestConjConjoiner(t *testing.T) {
 	}
 }
 
+func TestConjString(t *testing.T) {
+	if got := Conj("abc", 'd'); got != "abcd" {
+		t.Fatal("unexpected value", got)
+	}
+	if got := Conj("abc", "def"); got != "abcdef" {
+		t.Fatal("unexpected value", got)
+	}
+	if got := Conj("", byte('x')); got != "x" {
+		t.Fatal("unexpected value", got)
+	}
+	if got := Conj("a", byte(0xff)); got != "a\xff" {
+		t.Fatalf("unexpected value %q", got)
+	}
+	if got := Conj("", rune(0xff)); got != "ÿ" {
+		t.Fatalf("unexpected value %q", got)
+	}
+	type name string
+	if got := Conj(name("ab"), 'c'); got != name("abc") {
+		t.Fatal("unexpected value", got)
+	}
+	if err := quick.Check(func(s string) bool {
+		return Into("", Seq(s)) == s &&
+			Into("", Map(func(r rune) string {
+				return string(r)
+			}, s)) == s
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSequenceEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b interface{}
+		exp  bool
+	}{
+		{"range-slice", RangeUntil(3), Seq([]int{0, 1, 2}), true},
+		{"both-empty", nil, []int{}, true},
+		{"different-value", RangeUntil(3), []int{0, 5, 2}, false},
+		{"a-longer", RangeUntil(4), []int{0, 1, 2}, false},
+		{"b-longer", RangeUntil(3), []int{0, 1, 2, 3}, false},
+		{"different-type", RangeUntil(3), []int64{0, 1, 2}, false},
+		{"infinite", RepeateInfinitely(1), []int{1, 1, 2}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := SequenceEqual(test.a, test.b); got != test.exp {
+				t.Fatal("wanted", test.exp, "got", got)
+			}
+		})
+	}
+}
+
+func ExampleRotate() {
+	fmt.Println(Rotate(2, RangeUntil(5)))
+	// Output: (2 3 4 0 1)
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		exp  []int
+	}{
+		{"zero", 0, []int{0, 1, 2, 3, 4}},
+		{"negative", -2, []int{3, 4, 0, 1, 2}},
+		{"over-length", 7, []int{2, 3, 4, 0, 1}},
+		{"negative-over-length", -11, []int{4, 0, 1, 2, 3}},
+		{"length", 5, []int{0, 1, 2, 3, 4}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Into([]int{}, Rotate(test.n, RangeUntil(5)))
+			if !reflect.DeepEqual(got, test.exp) {
+				t.Fatal("wanted", test.exp, "got", got)
+			}
+		})
+	}
+	if Rotate(3, nil) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func ExampleSeparate() {
+	fmt.Println(Separate(func(x int) bool {
+		return x%2 == 0
+	}, RangeUntil(10)))
+	// Output: ((0 2 4 6 8) (1 3 5 7 9))
+}
+
+func TestSeparate(t *testing.T) {
+	calls := 0
+	parts := Separate(func(x int) bool {
+		calls++
+		return x > 2
+	}, []int{5, 1, 4, 2, 3})
+	no, yes := Into([]int{}, Second(parts)), Into([]int{}, First(parts))
+	if !reflect.DeepEqual(yes, []int{5, 4, 3}) ||
+		!reflect.DeepEqual(no, []int{1, 2}) {
+		t.Fatal("unexpected values", yes, no)
+	}
+	if calls != 5 {
+		t.Fatal("expected pred to be called once per element", calls)
+	}
+	got := Into([]int{}, Take(3, First(Separate(func(x int) bool {
+		return x%3 == 0
+	}, Iterate(func(x int) int { return x + 1 }, 0)))))
+	if !reflect.DeepEqual(got, []int{0, 3, 6}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
 func TestInvalidConj(t *testing.T) {
 	if err := quick.Check(func(i int, other int) (out bool) {
 		defer func() {
@@ -174,6 +365,67 @@ func ExampleMap() {
 	// Output: (0 2 4 6 8 10 12 14 16 18)
 }
 
+func TestMapIf(t *testing.T) {
+	isEven := func(x int) bool { return x%2 == 0 }
+	square := func(x int) int { return x * x }
+	negate := func(x int) int { return -x }
+	exp := "(0 -1 4 -3 16 -5)"
+	if got := fmt.Sprint(MapIf(isEven, square, negate, RangeUntil(6))); got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	exp = "(0 1 4 3 16 5)"
+	if got := fmt.Sprint(MapIf(isEven, square, nil, RangeUntil(6))); got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	got := Into([]interface{}{}, Annotate(func(x int) int {
+		return x * x
+	}, RangeUntil(4)))
+	exp := []interface{}{
+		[]interface{}{0, 0},
+		[]interface{}{1, 1},
+		[]interface{}{2, 4},
+		[]interface{}{3, 9},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if Seq(Annotate(func(x int) int { return x }, nil)) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func ExampleMapIf() {
+	fmt.Println(MapIf(func(x int) bool { return x%2 == 0 },
+		func(x int) int { return x * x },
+		func(x int) int { return -x },
+		RangeUntil(6)))
+	// Output: (0 -1 4 -3 16 -5)
+}
+
+func TestMapMulti(t *testing.T) {
+	double := func(a int) int { return a + a }
+	if got := fmt.Sprint(MapMulti(double, RangeUntil(3))); got != "(0 2 4)" {
+		t.Fatal("unexpected value", got)
+	}
+	if _, ok := MapMulti(double, RangeUntil(3)).(*xfrmSeq); !ok {
+		t.Fatal("expected single collection to use Map")
+	}
+	sum := func(a, b, c int) int { return a + b + c }
+	got := fmt.Sprint(MapMulti(sum, RangeUntil(3), RangeUntil(5), RangeUntil(4)))
+	if got != "(0 3 6)" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func ExampleMapMulti() {
+	fmt.Println(MapMulti(func(a, b int) int { return a + b },
+		RangeUntil(3), Range(10, 13, 1)))
+	// Output: (10 12 14)
+}
+
 func ExampleReplace() {
 	fmt.Println(Replace(map[int]int{
 		1: 10,
@@ -304,6 +556,161 @@ func TestReduceReflect(t *testing.T) {
 	}
 }
 
+func TestReduceDelegatesToReducer(t *testing.T) {
+	if err := quick.Check(func(is []int) bool {
+		if len(is) == 0 {
+			return Reduce(func(a, b int) int { return a + b }, 0, is) == 0
+		}
+		sum := func(a, b int) int {
+			return a + b
+		}
+		walked := reduceSeq(wrapReduce(sum), 0, IntSeq(is))
+		return walked == Reduce(sum, 0, is) &&
+			walked == Reduce(sum, 0, Seq(is)) &&
+			walked == Reduce(sum, 0, IntSeq(is))
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkReduce(b *testing.B) {
+	sum := func(a, b interface{}) interface{} {
+		return a.(int) + b.(int)
+	}
+	s := make([]int, 1000)
+	b.Run("slice-reducer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			Reduce(sum, 0, Seq(s))
+		}
+	})
+	b.Run("slice-walk", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reduceSeq(sum, 0, Seq(s))
+		}
+	})
+}
+
+func ExampleReductions() {
+	sum := func(a, b int) int {
+		return a + b
+	}
+	fmt.Println(Reductions(sum, 0, RangeUntil(5)))
+	fmt.Println(Take(4, Reductions(sum, 0, Range(1, 0, 0))))
+	// Output: (0 0 1 3 6 10)
+	// (0 1 2 3)
+}
+
+func TestReductions(t *testing.T) {
+	if err := quick.Check(func(is []int) bool {
+		sum := func(a, b int) int {
+			return a + b
+		}
+		steps := Slice(Reductions(sum, 0, is))
+		return len(steps) == len(is)+1 &&
+			steps[len(steps)-1] == Reduce(sum, 0, is)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReductionsReduced(t *testing.T) {
+	steps := 0
+	got := Into([]int{}, Reductions(func(res, x int) interface{} {
+		steps++
+		if res+x >= 10 {
+			return Reduced(res + x)
+		}
+		return res + x
+	}, 0, RepeateInfinitely(3)))
+	exp := []int{0, 3, 6, 9, 12}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if steps != 4 {
+		t.Fatal("unexpected number of steps", steps)
+	}
+}
+
+func TestReduceReduced(t *testing.T) {
+	steps := 0
+	got := Reduce(func(res, x int) interface{} {
+		steps++
+		if res+x >= 10 {
+			return Reduced(res + x)
+		}
+		return res + x
+	}, 0, RepeateInfinitely(3))
+	if got != 12 || steps != 4 {
+		t.Fatal("wanted 12 after 4 steps got", got, "after", steps)
+	}
+	if err := quick.Check(func(is []int, target int) bool {
+		find := func(res interface{}, x int) interface{} {
+			if x == target {
+				return Reduced(x)
+			}
+			return res
+		}
+		var expected interface{}
+		for _, v := range is {
+			if v == target {
+				expected = v
+				break
+			}
+		}
+		return Reduce(find, nil, is) == expected &&
+			Reduce(find, nil, IntSeq(is).seq()) == expected
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReduceErr(t *testing.T) {
+	errTooBig := errors.New("too big")
+	t.Run("success", func(t *testing.T) {
+		got, err := ReduceErr(func(res, x int) (int, error) {
+			return res + x, nil
+		}, 0, RangeUntil(10))
+		if err != nil || got != 45 {
+			t.Fatal("unexpected result", got, err)
+		}
+	})
+	t.Run("abort", func(t *testing.T) {
+		steps := 0
+		got, err := ReduceErr(func(res, x interface{}) (interface{}, error) {
+			steps++
+			if x.(int) > 3 {
+				return nil, errTooBig
+			}
+			return res.(int) + x.(int), nil
+		}, 0, RangeUntil(10))
+		if err != errTooBig || got != 0+1+2+3 || steps != 5 {
+			t.Fatal("unexpected result", got, err, steps)
+		}
+	})
+	t.Run("abort-reflect", func(t *testing.T) {
+		got, err := ReduceErr(func(res, x int) (int, error) {
+			if x > 3 {
+				return 0, errTooBig
+			}
+			return res + x, nil
+		}, 0, RepeateInfinitely(4))
+		if err != errTooBig || got != 0 {
+			t.Fatal("unexpected result", got, err)
+		}
+	})
+}
+
+func TestTransduceTake(t *testing.T) {
+	got := Transduce(transduce.Take(3), func(res, x int) int {
+		return res + x
+	}, 0, RangeUntil(10))
+	if got != 0+1+2 {
+		t.Fatal("unexpected value", got)
+	}
+}
+
 func ExampleReduce() {
 	fmt.Println(Reduce(func(a, b int) int {
 		return a + b
@@ -311,6 +718,115 @@ func ExampleReduce() {
 	// Output: 45
 }
 
+func TestTap(t *testing.T) {
+	var seen []int
+	s := Tap(func(x int) {
+		seen = append(seen, x)
+	}, RangeUntil(5))
+	if len(seen) != 0 {
+		t.Fatal("expected Tap to be lazy", seen)
+	}
+	if got := Into([]int{}, Take(2, s)); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Fatal("unexpected value", got)
+	}
+	if !reflect.DeepEqual(seen, []int{0, 1}) {
+		t.Fatal("unexpected observed values", seen)
+	}
+	first, second := Into([]int{}, s), Into([]int{}, s)
+	if !reflect.DeepEqual(first, []int{0, 1, 2, 3, 4}) ||
+		!reflect.DeepEqual(second, first) {
+		t.Fatal("unexpected output", first, second)
+	}
+	if !reflect.DeepEqual(seen, []int{0, 1, 2, 3, 4}) {
+		t.Fatal("expected fn to run once per element", seen)
+	}
+}
+
+func TestDoseq(t *testing.T) {
+	var got []int
+	Doseq(func(x int) {
+		got = append(got, x)
+	}, RangeUntil(4))
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Fatal("unexpected order", got)
+	}
+	var any []interface{}
+	Doseq(func(x interface{}) {
+		any = append(any, x)
+	}, []string{"a", "b"})
+	if !reflect.DeepEqual(any, []interface{}{"a", "b"}) {
+		t.Fatal("unexpected order", any)
+	}
+}
+
+func TestDoseqIndexed(t *testing.T) {
+	var got []string
+	DoseqIndexed(func(i int, s string) {
+		got = append(got, fmt.Sprint(i, s))
+	}, []string{"a", "b", "c"})
+	if !reflect.DeepEqual(got, []string{"0a", "1b", "2c"}) {
+		t.Fatal("unexpected order", got)
+	}
+	calls := 0
+	DoseqIndexed(func(int, interface{}) { calls++ }, nil)
+	if calls != 0 {
+		t.Fatal("unexpected calls", calls)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	visited := 0
+	ForEach(func(x int) bool {
+		visited++
+		return visited < 5
+	}, RepeateInfinitely(1))
+	if visited != 5 {
+		t.Fatal("expected to stop after 5 elements", visited)
+	}
+	var got []interface{}
+	ForEach(func(x interface{}) bool {
+		got = append(got, x)
+		return true
+	}, RangeUntil(3))
+	if !reflect.DeepEqual(got, []interface{}{0, 1, 2}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestDoRunContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	realized := 0
+	s := Map(func(x int) int {
+		realized++
+		if realized == 5 {
+			cancel()
+		}
+		return x
+	}, RepeateInfinitely(1))
+	if err := DoRunContext(ctx, s); err != context.Canceled {
+		t.Fatal("unexpected error", err)
+	}
+	if realized != 5 {
+		t.Fatal("expected realization to stop after 5 elements", realized)
+	}
+	if err := DoRunContext(context.Background(), RangeUntil(10)); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+}
+
+func TestDoAllContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := DoAllContext(ctx, RepeateInfinitely(1)); err != context.Canceled {
+		t.Fatal("unexpected error", err)
+	}
+	s, err := DoAllContext(context.Background(), RangeUntil(3))
+	if err != nil || fmt.Sprint(s) != "(0 1 2)" {
+		t.Fatal("unexpected result", s, err)
+	}
+}
+
 func TestSlice(t *testing.T) {
 	if err := quick.Check(func(is []int) bool {
 		got := Slice(Seq(is))
@@ -326,11 +842,206 @@ func TestSlice(t *testing.T) {
 	}
 }
 
+func TestSliceOf(t *testing.T) {
+	got := SliceOf(RangeUntil(5), 0).([]int)
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4}) {
+		t.Fatal("unexpected value", got)
+	}
+	strs := SliceOf(Map(func(x int) string {
+		return fmt.Sprint(x)
+	}, RangeUntil(3)), "").([]string)
+	if !reflect.DeepEqual(strs, []string{"0", "1", "2"}) {
+		t.Fatal("unexpected value", strs)
+	}
+	if got := SliceOf(nil, 0).([]int); len(got) != 0 {
+		t.Fatal("expected empty slice got", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for mismatched element")
+		}
+	}()
+	SliceOf([]interface{}{1, "a"}, 0)
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Sequence
+		opts FormatOptions
+		exp  string
+	}{
+		{"brackets", RangeUntil(3),
+			FormatOptions{Open: "[", Close: "]", Sep: ", "}, "[0, 1, 2]"},
+		{"empty", nil,
+			FormatOptions{Open: "<", Close: ">", Sep: "|"}, "<>"},
+		{"no-delimiters", RangeUntil(3), FormatOptions{Sep: "-"}, "0-1-2"},
+		{"truncated", RepeateInfinitely("x"),
+			FormatOptions{Open: "(", Close: ")", Sep: " ", MaxElements: 3},
+			"(x x x ...)"},
+		{"exact-limit", RangeUntil(3),
+			FormatOptions{Open: "(", Close: ")", Sep: " ", MaxElements: 3},
+			"(0 1 2)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Format(test.in, test.opts); got != test.exp {
+				t.Fatal("wanted", test.exp, "got", got)
+			}
+		})
+	}
+	if got := ConvertToString(RangeUntil(3)); got != "(0 1 2)" {
+		t.Fatal("unexpected default format", got)
+	}
+}
+
+func TestStringInfinite(t *testing.T) {
+	done := make(chan struct{})
+	var repeat, cycle string
+	go func() {
+		defer close(done)
+		repeat = fmt.Sprint(RepeateInfinitely("x"))
+		cycle = fmt.Sprint(Cycle([]int{1, 2}))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("printing an infinite sequence did not terminate")
+	}
+	if repeat != "(x x x x x x x x x x ...)" {
+		t.Fatal("unexpected value", repeat)
+	}
+	if cycle != "(1 2 1 2 1 2 1 2 1 2 ...)" {
+		t.Fatal("unexpected value", cycle)
+	}
+	if got := fmt.Sprint(Repeat(3, "x")); got != "(x x x)" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestSetPrintLimit(t *testing.T) {
+	SetPrintLimit(3)
+	defer SetPrintLimit(0)
+	if got := fmt.Sprint(RepeateInfinitely("x")); got != "(x x x ...)" {
+		t.Fatal("unexpected value", got)
+	}
+	if got := fmt.Sprint(RangeUntil(10)); got != "(0 1 2 ...)" {
+		t.Fatal("unexpected value", got)
+	}
+	if got := fmt.Sprint(RangeUntil(3)); got != "(0 1 2)" {
+		t.Fatal("unexpected value", got)
+	}
+	SetPrintLimit(0)
+	if got := fmt.Sprint(RangeUntil(5)); got != "(0 1 2 3 4)" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
 func ExampleSlice() {
 	fmt.Println(Slice(RangeUntil(10)))
 	// Output: [0 1 2 3 4 5 6 7 8 9]
 }
 
+func TestToMap(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2}
+	got := ToMap(m)
+	exp := map[interface{}]interface{}{"a": 1, "b": 2}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	got = ToMap([][]interface{}{{"a", 1}, {"b", 2}, {"a", 3}})
+	exp = map[interface{}]interface{}{"a": 3, "b": 2}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if got := ToMap(nil); len(got) != 0 {
+		t.Fatal("expected empty map got", got)
+	}
+}
+
+func TestToMapInvalid(t *testing.T) {
+	for _, in := range []interface{}{
+		[]int{1, 2},
+		[][]interface{}{{"a", 1, 2}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected panic for", in)
+				}
+			}()
+			ToMap(in)
+		}()
+	}
+}
+
+func TestToSet(t *testing.T) {
+	if err := quick.Check(func(is []int8) bool {
+		set := ToSet(is)
+		for _, v := range is {
+			if _, ok := set[v]; !ok {
+				return false
+			}
+		}
+		return len(set) == len(Slice(Distinct(is)))
+	}, nil); err != nil {
+		t.Error(err)
+	}
+	type key struct{ v interface{} }
+	for _, in := range []interface{}{[][]int{{1}}, []key{{[]int{1}}}} {
+		func() {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !strings.Contains(err.Error(), "not hashable") {
+					t.Fatal("expected hashable error, got", err)
+				}
+			}()
+			ToSet(in)
+		}()
+	}
+}
+
+func TestKeysVals(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	keys := Into([]string{}, Keys(m)).([]string)
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Fatal("unexpected keys", keys)
+	}
+	vals := Into([]int{}, Vals(Seq(m))).([]int)
+	sort.Ints(vals)
+	if !reflect.DeepEqual(vals, []int{1, 2, 3}) {
+		t.Fatal("unexpected values", vals)
+	}
+	entries := []MapEntry{mapEntry{key: "x", val: 10}}
+	if First(Keys(entries)) != "x" || First(Vals(entries)) != 10 {
+		t.Fatal("unexpected projection of entry slice")
+	}
+	if Seq(Keys(nil)) != nil || Seq(Vals(nil)) != nil {
+		t.Fatal("expected empty sequences")
+	}
+}
+
+func ExampleCountBy() {
+	fmt.Println(CountBy(func(x int) bool {
+		return x%2 == 0
+	}, RangeUntil(10)))
+	// Output: map[false:5 true:5]
+}
+
+func TestCountBy(t *testing.T) {
+	if err := quick.Check(func(is []int8) bool {
+		key := func(x int8) int8 { return x % 3 }
+		exp := map[interface{}]int{}
+		for _, v := range is {
+			exp[key(v)]++
+		}
+		return reflect.DeepEqual(CountBy(key, is), exp)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestRange(t *testing.T) {
 	t.Run("step>zero&&start<end", func(t *testing.T) {
 		rng := RangeBetween(1, 10)
@@ -412,11 +1123,87 @@ func TestRange(t *testing.T) {
 	})
 }
 
+func TestRangeOverflow(t *testing.T) {
+	tests := []struct {
+		name             string
+		start, end, step int
+		expected         []int
+	}{
+		{"MaxInt", math.MaxInt - 2, math.MaxInt, 1,
+			[]int{math.MaxInt - 2, math.MaxInt - 1}},
+		{"MaxIntStep", math.MaxInt - 5, math.MaxInt, 3,
+			[]int{math.MaxInt - 5, math.MaxInt - 2}},
+		{"MaxIntLargeStep", 0, math.MaxInt, math.MaxInt / 2,
+			[]int{0, math.MaxInt / 2, math.MaxInt/2 + math.MaxInt/2}},
+		{"MinInt", math.MinInt + 2, math.MinInt, -1,
+			[]int{math.MinInt + 2, math.MinInt + 1}},
+		{"MinIntStep", math.MinInt + 5, math.MinInt, -3,
+			[]int{math.MinInt + 5, math.MinInt + 2}},
+		{"MinIntLargeStep", 0, math.MinInt, math.MinInt / 2,
+			[]int{0, math.MinInt / 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Slice(Take(10, Range(test.start, test.end, test.step)))
+			if len(got) != len(test.expected) {
+				t.Fatal("wanted", test.expected, "got", got)
+			}
+			for i, v := range test.expected {
+				if got[i] != v {
+					t.Fatal("wanted", v, "got", got[i], "at", i)
+				}
+			}
+		})
+	}
+}
+
 func ExampleRange() {
 	fmt.Println(Range(1, 10, 2))
 	// Output: (1 3 5 7 9)
 }
 
+func TestRangeFloat(t *testing.T) {
+	t.Run("step>zero", func(t *testing.T) {
+		got := fmt.Sprint(RangeFloat(0, 2, 0.5))
+		if got != "(0 0.5 1 1.5)" {
+			t.Fatal("unexpected value", got)
+		}
+	})
+	t.Run("step<zero", func(t *testing.T) {
+		got := fmt.Sprint(RangeFloat(1, -1, -0.5))
+		if got != "(1 0.5 0 -0.5)" {
+			t.Fatal("unexpected value", got)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		if RangeFloat(1, 1, 0.5) != nil {
+			t.Fatal("expected empty sequence")
+		}
+		if RangeFloat(0, 1, -0.5) != nil {
+			t.Fatal("expected empty sequence")
+		}
+		if RangeFloat(1, 0, 0.5) != nil {
+			t.Fatal("expected empty sequence")
+		}
+		if RangeFloat(1, 1, 0) != nil {
+			t.Fatal("expected empty sequence")
+		}
+	})
+	t.Run("no accumulated drift", func(t *testing.T) {
+		last := Reduce(func(_, x interface{}) interface{} {
+			return x
+		}, nil, RangeFloat(0, 100, 0.1))
+		if math.Abs(last.(float64)-99.9) > 1e-9 {
+			t.Fatal("unexpected last value", last)
+		}
+	})
+}
+
+func ExampleRangeFloat() {
+	fmt.Println(RangeFloat(0, 1, 0.25))
+	// Output: (0 0.25 0.5 0.75)
+}
+
 func ExampleRangeBetween() {
 	fmt.Println(RangeBetween(1, 5))
 	// Output: (1 2 3 4)
@@ -487,6 +1274,82 @@ func ExampleIterate() {
 	// Output: (2 4 8 16 32 64 128 256 512 1024)
 }
 
+func TestIterateRetry(t *testing.T) {
+	failures := 0
+	flaky := func(x int) int {
+		if failures < 2 {
+			failures++
+			panic("transient failure")
+		}
+		failures = 0
+		return x + x
+	}
+	exp := "(2 4 8 16 32)"
+	got := fmt.Sprint(Take(5, IterateRetry(flaky, 2, 2, time.Millisecond)))
+	if got != exp {
+		t.Fatalf("IterateRetry didn't return expected. got %s expected %s",
+			got, exp,
+		)
+	}
+}
+
+func TestIterateRetryCallsOncePerElement(t *testing.T) {
+	calls := 0
+	poll := func(x int) int {
+		calls++
+		return x + 1
+	}
+	s := IterateRetry(poll, 0, 1, 0)
+	third := Next(Next(s))
+	for i := 0; i < 3; i++ {
+		if got := First(third); got != 2 {
+			t.Fatal("unexpected value", got)
+		}
+	}
+	if calls != 2 {
+		t.Fatal("expected 2 calls, got", calls)
+	}
+}
+
+func TestIterateRetryGivesUp(t *testing.T) {
+	failing := func(x int) int {
+		panic("permanent failure")
+	}
+	defer func() {
+		if r := recover(); r != "permanent failure" {
+			t.Fatal("unexpected panic", r)
+		}
+	}()
+	First(Next(IterateRetry(failing, 1, 3, 0)))
+	t.Fatal("expected panic")
+}
+
+func TestRecurrence(t *testing.T) {
+	add := func(a, b int) int {
+		return a + b
+	}
+	expected := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}
+	seq := Seq(Take(10, Recurrence(add, 0, 1)))
+	for idx, i := range expected {
+		f := First(seq)
+		if i != f {
+			t.Fatal("wanted", i, "got", f, "at", idx)
+		}
+		seq = Next(seq)
+	}
+	if seq != nil {
+		t.Fatal("unexpected value", seq)
+	}
+}
+
+func ExampleRecurrence() {
+	add := func(a, b int) int {
+		return a + b
+	}
+	fmt.Println(Take(10, Recurrence(add, 0, 1)))
+	// Output: (0 1 1 2 3 5 8 13 21 34)
+}
+
 func TestCycle(t *testing.T) {
 	cyc := Cycle(RangeUntil(10))
 	expected := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9,
@@ -504,6 +1367,19 @@ func TestCycle(t *testing.T) {
 	}
 }
 
+func TestCycleEmpty(t *testing.T) {
+	cyc := Cycle(Seq([]int{}))
+	if cyc != nil {
+		t.Fatal("unexpected value", cyc)
+	}
+	if First(cyc) != nil || Next(cyc) != nil {
+		t.Fatal("unexpected value", cyc)
+	}
+	if Cycle(nil) != nil {
+		t.Fatal("unexpected value", Cycle(nil))
+	}
+}
+
 func ExampleCycle() {
 	fmt.Println(Take(15, Cycle(RangeUntil(10))))
 	// Output: (0 1 2 3 4 5 6 7 8 9 0 1 2 3 4)
@@ -571,6 +1447,49 @@ func TestInterleaveUneven(t *testing.T) {
 	}
 }
 
+func ExampleInterleaveAll() {
+	fmt.Println(InterleaveAll(
+		[]string{"a", "b", "c"},
+		[]int{1},
+		[]float64{0.5, 1.5},
+	))
+	// Output: (a 1 0.5 b 1.5 c)
+}
+
+func TestInterleaveAll(t *testing.T) {
+	got := Into([]int{}, InterleaveAll(
+		RangeUntil(3), Range(10, 11, 1), Range(20, 22, 1)))
+	exp := []int{0, 10, 20, 1, 21, 2}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func TestStableMerge(t *testing.T) {
+	a := []string{"a0", "a1", "a2", "a3"}
+	b := []string{"b0"}
+	c := []string{"c0", "c1"}
+	got := Into([]string{}, StableMerge(a, b, nil, c))
+	exp := []string{"a0", "b0", "c0", "a1", "c1", "a2", "a3"}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	for _, src := range [][]string{a, b, c} {
+		var filtered []string
+		for _, v := range got.([]string) {
+			if v[0] == src[0][0] {
+				filtered = append(filtered, v)
+			}
+		}
+		if !reflect.DeepEqual(filtered, src) {
+			t.Fatal("source order not preserved", filtered, src)
+		}
+	}
+	if Seq(StableMerge()) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
 func ExampleInterleave() {
 	s1 := []int{1, 2, 3, 4, 5, 6}
 	s2 := []int{7, 8, 9, 10, 11, 12}
@@ -579,6 +1498,143 @@ func ExampleInterleave() {
 	// Output: (1 7 13 2 8 14 3 9 15 4 10 16 5 11 17 6 12 18)
 }
 
+func TestLazySeqRunsOnce(t *testing.T) {
+	once := func(fn func() Sequence) func() Sequence {
+		called := false
+		return func() Sequence {
+			if called {
+				panic("thunk evaluated twice")
+			}
+			called = true
+			return fn()
+		}
+	}
+	s := LazySeq(once(func() Sequence {
+		return LazySeq(once(func() Sequence {
+			return Cons(1, LazySeq(once(func() Sequence {
+				return Cons(2, nil)
+			})))
+		}))
+	}))
+	for i := 0; i < 3; i++ {
+		if First(s) != 1 || Second(s) != 2 || Next(Next(s)) != nil {
+			t.Fatal("unexpected value", s)
+		}
+		if got := fmt.Sprint(s); got != "(1 2)" {
+			t.Fatal("unexpected value", got)
+		}
+	}
+	empty := LazySeq(once(func() Sequence {
+		return Filter(func(x int) bool { return false }, RangeUntil(3))
+	}))
+	if Seq(empty) != nil || Seq(empty) != nil || First(empty) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func TestLazyCat(t *testing.T) {
+	var fib func(a, b int) Sequence
+	fib = func(a, b int) Sequence {
+		return LazyCat(
+			func() Sequence { return Cons(a, nil) },
+			func() Sequence { return fib(b, a+b) },
+		)
+	}
+	expected := []int{0, 1, 1, 2, 3, 5, 8, 13, 21, 34}
+	seq := Seq(Take(10, fib(0, 1)))
+	for idx, i := range expected {
+		f := First(seq)
+		if i != f {
+			t.Fatal("wanted", i, "got", f, "at", idx)
+		}
+		seq = Next(seq)
+	}
+	if seq != nil {
+		t.Fatal("unexpected value", seq)
+	}
+}
+
+func TestLazyCatIsLazy(t *testing.T) {
+	called := false
+	seq := LazyCat(
+		func() Sequence { return RangeUntil(3) },
+		func() Sequence {
+			called = true
+			return RangeUntil(3)
+		},
+	)
+	if fmt.Sprint(Take(3, seq)) != "(0 1 2)" || called {
+		t.Fatal("second sequence was realized early")
+	}
+	if fmt.Sprint(seq) != "(0 1 2 0 1 2)" || !called {
+		t.Fatal("unexpected value", seq)
+	}
+}
+
+func ExampleLazyCat() {
+	fmt.Println(LazyCat(
+		func() Sequence { return RangeUntil(3) },
+		func() Sequence { return nil },
+		func() Sequence { return Seq([]int{7, 8, 9}) },
+	))
+	// Output: (0 1 2 7 8 9)
+}
+
+func TestZip(t *testing.T) {
+	got := Slice(Zip(RangeUntil(5), Seq([]string{"a", "b", "c"}),
+		RepeateInfinitely(true)))
+	exp := "[[0 a true] [1 b true] [2 c true]]"
+	if fmt.Sprint(got) != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if _, ok := got[0].([]interface{}); !ok {
+		t.Fatalf("unexpected tuple type %T", got[0])
+	}
+	if Seq(Zip()) != nil || Seq(Zip(RangeUntil(3), nil)) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	sum := func(a, b, c int) int { return a + b + c }
+	got := fmt.Sprint(ZipWith(sum, RangeUntil(10), RangeBetween(10, 13),
+		Repeat(4, 100)))
+	if got != "(110 112 114)" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestCombine(t *testing.T) {
+	sum := func(a, b int) int { return a + b }
+	if got := fmt.Sprint(Combine(sum, RangeUntil(5), RangeBetween(10, 13))); got != "(10 12 14)" {
+		t.Fatal("unexpected value", got)
+	}
+	if got := fmt.Sprint(Combine(sum, RangeBetween(10, 13), RangeUntil(5))); got != "(10 12 14)" {
+		t.Fatal("unexpected value", got)
+	}
+	if Seq(Combine(sum, nil, RangeUntil(5))) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func ExampleCombine() {
+	fmt.Println(Combine(func(a, b int) int { return a * b },
+		RangeUntil(4), Repeat(10, 3)))
+	// Output: (0 3 6 9)
+}
+
+func ExampleZip() {
+	fmt.Println(Zip(RangeUntil(3), Seq([]string{"a", "b", "c"})))
+	// Output: ([0 a] [1 b] [2 c])
+}
+
+func ExampleZipWith() {
+	fmt.Println(ZipWith(func(i int, s string) string {
+		return fmt.Sprint(s, i)
+	}, RangeUntil(3), Seq([]string{"a", "b", "c"})))
+	// Output: (a0 b1 c2)
+}
+
 func TestInterpose(t *testing.T) {
 	if err := quick.Check(func(s string, is []int) bool {
 		ipos := Interpose(s, Seq(is))
@@ -651,6 +1707,61 @@ func ExampleSome() {
 	// Output: true
 }
 
+func TestContains(t *testing.T) {
+	if err := quick.Check(func(n uint8) bool {
+		return Contains(RangeUntil(100), int(n)) == (n < 100)
+	}, nil); err != nil {
+		t.Error(err)
+	}
+	if !Contains(Cycle(RangeUntil(100)), 99) {
+		t.Fatal("expected to find 99")
+	}
+	if !Contains([][]int{{1}, {2}}, []int{2}) {
+		t.Fatal("expected to find [2]")
+	}
+	if Contains(nil, nil) {
+		t.Fatal("empty sequence contains nothing")
+	}
+}
+
+func ExampleContains() {
+	fmt.Println(Contains(RangeUntil(100), 42))
+	// Output: true
+}
+
+func TestIndexOf(t *testing.T) {
+	if idx := IndexOf(RangeUntil(10), 4); idx != 4 {
+		t.Fatal("wanted", 4, "got", idx)
+	}
+	if idx := IndexOf([]int{1, 2, 1}, 1); idx != 0 {
+		t.Fatal("wanted", 0, "got", idx)
+	}
+	if idx := IndexOf(RangeUntil(10), 10); idx != -1 {
+		t.Fatal("wanted", -1, "got", idx)
+	}
+	if idx := IndexOf([][]int{{1}, {2}}, []int{2}); idx != 1 {
+		t.Fatal("wanted", 1, "got", idx)
+	}
+	if idx := IndexOf(Cycle(RangeUntil(3)), 2); idx != 2 {
+		t.Fatal("wanted", 2, "got", idx)
+	}
+}
+
+func TestPositionBy(t *testing.T) {
+	over := func(n int) func(int) bool {
+		return func(x int) bool { return x > n }
+	}
+	if idx := PositionBy(over(5), []int{1, 7, 3, 9}); idx != 1 {
+		t.Fatal("wanted", 1, "got", idx)
+	}
+	if idx := PositionBy(over(9), []int{1, 7, 3, 9}); idx != -1 {
+		t.Fatal("wanted", -1, "got", idx)
+	}
+	if idx := PositionBy(over(100), RangeUntil(1000000000)); idx != 101 {
+		t.Fatal("wanted", 101, "got", idx)
+	}
+}
+
 func ExampleNotEvery() {
 	fmt.Println(NotEvery(func(x int) bool { return x == 10 },
 		Repeat(100, 10)))
@@ -691,12 +1802,59 @@ func ExampleMapcat() {
 	// Output: (1 3 5 7 9)
 }
 
+func TestFlatMap(t *testing.T) {
+	nested := []interface{}{
+		1,
+		[]interface{}{2, []int{3, 4}, []interface{}{}},
+		RangeBetween(5, 7),
+		[]interface{}{[]interface{}{[]interface{}{7}}},
+	}
+	double := func(x int) int { return x * 2 }
+	exp := "(2 4 6 8 10 12 14)"
+	if got := fmt.Sprint(FlatMap(double, nested)); got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	exp = "(ab 3 cd)"
+	got := fmt.Sprint(FlatMap(func(x interface{}) interface{} { return x },
+		[]interface{}{"ab", []interface{}{3, "cd"}}))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func ExampleFlatMap() {
+	nested := []interface{}{1, []interface{}{2, []int{3, 4}}, 5}
+	fmt.Println(FlatMap(func(x int) int { return x * 2 }, nested))
+	// Output: (2 4 6 8 10)
+}
+
 func ExampleTakeWhile() {
 	fmt.Println(TakeWhile(func(x int) bool { return x < 9 },
 		RangeUntil(20)))
 	// Output: (0 1 2 3 4 5 6 7 8)
 }
 
+func TestTakeUntilValue(t *testing.T) {
+	got := fmt.Sprint(TakeUntilValue(3, Cycle(RangeUntil(5))))
+	if got != "(0 1 2)" {
+		t.Fatal("unexpected value", got)
+	}
+	got = fmt.Sprint(TakeUntilValue([]int{}, Seq([][]int{{1}, {2}, {}, {3}})))
+	if got != "([1] [2])" {
+		t.Fatal("unexpected value", got)
+	}
+	got = fmt.Sprint(TakeUntilValue(10, RangeUntil(5)))
+	if got != "(0 1 2 3 4)" {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func ExampleTakeUntilValue() {
+	fmt.Println(TakeUntilValue("END",
+		Seq([]string{"HELLO", "WORLD", "END", "IGNORED"})))
+	// Output: (HELLO WORLD)
+}
+
 func ExampleDropWhile() {
 	fmt.Println(DropWhile(func(x int) bool { return x < 9 },
 		RangeUntil(20)))
@@ -730,12 +1888,218 @@ func ExampleDedupe() {
 	// Output: (1 2 3)
 }
 
+func TestRunningExtent(t *testing.T) {
+	less := func(a, b interface{}) bool { return a.(int) < b.(int) }
+	exp := "([5 5] [3 5] [3 8] [3 8] [1 8] [1 9])"
+	got := fmt.Sprint(RunningExtent(less, []int{5, 3, 8, 4, 1, 9}))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if RunningExtent(less, nil) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func ExampleRunningExtent() {
+	fmt.Println(RunningExtent(func(a, b interface{}) bool {
+		return a.(float64) < b.(float64)
+	}, []float64{0.5, -1, 2}))
+	// Output: ([0.5 0.5] [-1 0.5] [-1 2])
+}
+
+type sample struct {
+	key string
+	val int
+}
+
+func ExampleDedupeBy() {
+	fmt.Println(DedupeBy(func(x int) int {
+		return x / 10
+	}, []int{1, 5, 12, 18, 3, 31, 35, 14}))
+	// Output: (1 12 3 31 14)
+}
+
+func TestRandomSampleSeeded(t *testing.T) {
+	sample := func() interface{} {
+		rng := rand.New(rand.NewSource(42))
+		return Into([]int{}, RandomSampleSeeded(0.5, rng, RangeUntil(100)))
+	}
+	first, second := sample(), sample()
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("selection not reproducible", first, second)
+	}
+	if n := len(first.([]int)); n == 0 || n == 100 {
+		t.Fatal("unexpected sample size", n)
+	}
+	if got := Slice(RandomSample(0, RangeUntil(10))); len(got) != 0 {
+		t.Fatal("expected no elements got", got)
+	}
+	if got := Slice(RandomSample(1, RangeUntil(10))); len(got) != 10 {
+		t.Fatal("expected all elements got", got)
+	}
+}
+
+func TestRandomSampleInvalid(t *testing.T) {
+	for _, prob := range []float64{-0.1, 1.1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected panic for", prob)
+				}
+			}()
+			RandomSample(prob, RangeUntil(10))
+		}()
+	}
+}
+
+func ExampleInterposeBy() {
+	fmt.Println(InterposeBy(func(prev, next int) string {
+		return fmt.Sprintf("+%d", next-prev)
+	}, []int{1, 2, 4, 7}))
+	fmt.Println(InterposeBy(func(prev, next int) int {
+		return next - prev
+	}, RangeUntil(5)))
+	// Output: (1 +1 2 +2 4 +3 7)
+	// (0 1 1 1 2 1 3 1 4)
+}
+
+func TestInterposeByTake(t *testing.T) {
+	calls := 0
+	got := Into([]int{}, Take(4, InterposeBy(func(a, b int) int {
+		calls++
+		return -1
+	}, RangeUntil(100))))
+	if !reflect.DeepEqual(got, []int{0, -1, 1, -1}) {
+		t.Fatal("unexpected value", got)
+	}
+	if calls != 2 {
+		t.Fatal("expected 2 separators to be computed, got", calls)
+	}
+	if Seq(InterposeBy(func(a, b int) int { return 0 }, nil)) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func TestDedupeBy(t *testing.T) {
+	dedupeBy := func(key func(int) int, is []int) []int {
+		out := []int{}
+		for i, v := range is {
+			if i == 0 || key(v) != key(is[i-1]) {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+	if err := quick.Check(func(is []int8) bool {
+		ints := make([]int, len(is))
+		for i, v := range is {
+			ints[i] = int(v)
+		}
+		key := func(x int) int { return x / 10 }
+		got := Into([]int{}, DedupeBy(key, ints))
+		return reflect.DeepEqual(got, dedupeBy(key, ints))
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDedupeTap(t *testing.T) {
+	var dropped []interface{}
+	got := Into([]int{}, DedupeTap(func(x interface{}) {
+		dropped = append(dropped, x)
+	}, []int{1, 1, 2, 3, 3, 3, 1}))
+	if !reflect.DeepEqual(got, []int{1, 2, 3, 1}) {
+		t.Fatal("unexpected value", got)
+	}
+	if !reflect.DeepEqual(dropped, []interface{}{1, 3, 3}) {
+		t.Fatal("unexpected dropped values", dropped)
+	}
+	in := []interface{}{nil, nil, 1, nil}
+	dropped = nil
+	gotNil := Slice(DedupeTap(func(x interface{}) {
+		dropped = append(dropped, x)
+	}, in))
+	exp := Slice(Dedupe(in))
+	if !reflect.DeepEqual(gotNil, exp) || len(exp) != 2 {
+		t.Fatal("expected", exp, "got", gotNil)
+	}
+	if !reflect.DeepEqual(dropped, []interface{}{nil, nil}) {
+		t.Fatal("unexpected dropped values", dropped)
+	}
+	dropped = nil
+	slices := Slice(DedupeTap(func(x interface{}) {
+		dropped = append(dropped, x)
+	}, []interface{}{[]int{1}, []int{1}, []int{2}}))
+	if len(slices) != 2 || len(dropped) != 1 {
+		t.Fatal("unexpected values", slices, dropped)
+	}
+}
+
+func TestDedupeSum(t *testing.T) {
+	key := func(s sample) string { return s.key }
+	val := func(s sample) int { return s.val }
+	samples := []sample{{"a", 1}, {"a", 2}, {"b", 3}}
+	exp := "([a 3] [b 3])"
+	if got := fmt.Sprint(DedupeSum(key, val, samples)); got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	samples = []sample{{"a", 1}, {"b", 2}, {"b", 2}, {"a", 4}, {"a", 1}}
+	exp = "([a 1] [b 4] [a 5])"
+	if got := fmt.Sprint(DedupeSum(key, val, samples)); got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	exp = "([0 3] [1 12] [2 21])"
+	got := fmt.Sprint(Take(3, DedupeSum(func(x int) int { return x / 3 },
+		func(x int) int { return x }, RangeUntil(1000000))))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	samples = []sample{{"a", 1}, {"a", 2}, {"b", 3}}
+	m := ToMap(DedupeSum(key, val, samples))
+	if !reflect.DeepEqual(m, map[interface{}]interface{}{"a": 3, "b": 3}) {
+		t.Fatal("unexpected map", m)
+	}
+}
+
+func ExampleDedupeSum() {
+	fmt.Println(DedupeSum(
+		func(x float64) bool { return x < 0 },
+		func(x float64) float64 { return x },
+		[]float64{1, 2.5, -1, -2, 3}))
+	// Output: ([false 3.5] [true -3] [false 3])
+}
+
 func ExampleSplitWith() {
 	fmt.Println(SplitWith(func(x int) bool { return x < 9 },
 		RangeUntil(20)))
 	// Output: ((0 1 2 3 4 5 6 7 8) (9 10 11 12 13 14 15 16 17 18 19))
 }
 
+func TestSplitAtIndependent(t *testing.T) {
+	for _, tailFirst := range []bool{false, true} {
+		calls := 0
+		head, tail := SplitAtIndependent(3,
+			recomputingSeq{end: 6, calls: &calls})
+		var got []string
+		if tailFirst {
+			got = []string{fmt.Sprint(tail), fmt.Sprint(head)}
+			got[0], got[1] = got[1], got[0]
+		} else {
+			got = []string{fmt.Sprint(head), fmt.Sprint(tail)}
+		}
+		if got[0] != "(0 1 4)" || got[1] != "(9 16 25)" {
+			t.Fatal("unexpected value", got)
+		}
+		if calls != 6 {
+			t.Fatal("Next called", calls, "times, expected 6")
+		}
+	}
+	head, tail := SplitAtIndependent(3, RangeUntil(2))
+	if fmt.Sprint(head) != "(0 1)" || Seq(tail) != nil {
+		t.Fatal("unexpected value", head, tail)
+	}
+}
+
 func ExampleSplitAt() {
 	fmt.Println(SplitAt(9, RangeUntil(20)))
 	// Output: ((0 1 2 3 4 5 6 7 8) (9 10 11 12 13 14 15 16 17 18 19))
@@ -753,6 +2117,150 @@ func ExamplePartitionAll() {
 	// Output: ((0 1 2 3) (4 5 6 7) (8 9))
 }
 
+func TestPartitionAllInfinite(t *testing.T) {
+	got := Slice(Take(3, PartitionAll(4, RepeateInfinitely(1))))
+	if len(got) != 3 {
+		t.Fatal("wanted 3 partitions got", len(got))
+	}
+	for idx, part := range got {
+		if fmt.Sprint(part) != "(1 1 1 1)" {
+			t.Fatal("unexpected partition", part, "at", idx)
+		}
+	}
+	realized := 0
+	src := Map(func(x int) int {
+		realized++
+		return x
+	}, RangeUntil(1000))
+	exp := "((0 1 2 3) (4 5 6 7))"
+	if got := fmt.Sprint(Take(2, PartitionAll(4, src))); got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if realized != 8 {
+		t.Fatal("realized", realized, "elements, expected 8")
+	}
+}
+
+func TestXfrmSequenceFlushesOnCompletion(t *testing.T) {
+	isOdd := func(x int) bool { return x%2 != 0 }
+	exp := "((1 1) (2))"
+	got := fmt.Sprint(PartitionBy(isOdd, Seq([]int{1, 1, 2})))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	exp = "((1 2 3) (4))"
+	got = fmt.Sprint(PartitionAll(3, LazyCat(
+		func() Sequence { return RangeBetween(1, 5) },
+		func() Sequence { return nil },
+	)))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	xf := transduce.Compose(transduce.Take(3), transduce.PartitionAll(2))
+	exp = fmt.Sprint(Transduce(xf, Conj, []interface{}{}, RangeUntil(10)))
+	if exp != "[[0 1] [2]]" {
+		t.Fatal("unexpected reference value", exp)
+	}
+	got = fmt.Sprint(Into([]interface{}{}, XfrmSequence(xf, RangeUntil(10))))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func TestXfrmSequenceRealizesOnce(t *testing.T) {
+	steps := 0
+	in := []int{1, 1, 2, 2, 2, 3, 1, 1}
+	s := XfrmSequence(transduce.Compose(
+		transduce.Map(func(x int) int {
+			steps++
+			return x
+		}),
+		transduce.Dedupe(),
+	), Seq(in))
+	var wg sync.WaitGroup
+	results := make([]interface{}, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			node := s
+			// Realize every node from each goroutine, some
+			// nodes more than once.
+			Seq(node)
+			results[i] = Into([]int{}, node)
+		}(i)
+	}
+	wg.Wait()
+	for _, got := range results {
+		if !reflect.DeepEqual(got, []int{1, 2, 3, 1}) {
+			t.Fatal("unexpected value", got)
+		}
+	}
+	if steps != len(in) {
+		t.Fatal("transducer stepped more than once per input", steps)
+	}
+	next := Next(s)
+	if !reflect.DeepEqual(Into([]int{}, next), Into([]int{}, next)) {
+		t.Fatal("re-realizing a node changed its value")
+	}
+}
+
+func TestXfrmSequenceEmptyIsStable(t *testing.T) {
+	s := XfrmSequence(transduce.Filter(func(x int) bool {
+		return false
+	}), Seq([]int{1, 2, 3}))
+	if Seq(s) != nil || Seq(s) != nil {
+		t.Fatal("expected an empty sequence every time it is realized")
+	}
+	if First(s) != nil || Next(s) != nil {
+		t.Fatal("expected an empty sequence")
+	}
+}
+
+func TestXfrmPerPartition(t *testing.T) {
+	tens := func(x int) int { return x / 10 }
+	exp := "(0 1 10 11 20 21)"
+	got := fmt.Sprint(XfrmPerPartition(tens, transduce.Take(2),
+		RangeUntil(25)))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	exp = "(1 2 1 3)"
+	got = fmt.Sprint(XfrmPerPartition(func(x int) bool { return x < 3 },
+		transduce.Dedupe(), Seq([]int{1, 1, 2, 2, 1, 3, 3})))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if Seq(XfrmPerPartition(tens, transduce.Take(2), nil)) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func ExampleXfrmPerPartition() {
+	tens := func(x int) int { return x / 10 }
+	fmt.Println(XfrmPerPartition(tens, transduce.Take(2), RangeUntil(25)))
+	// Output: (0 1 10 11 20 21)
+}
+
+func TestList(t *testing.T) {
+	if got := fmt.Sprint(List(1, 2, 3)); got != "(1 2 3)" {
+		t.Fatal("unexpected value", got)
+	}
+	if List() != nil {
+		t.Fatal("expected empty list to be nil")
+	}
+}
+
+func TestConsAll(t *testing.T) {
+	got := Into([]int{}, ConsAll(RangeUntil(2), 7, 8, 9))
+	if !reflect.DeepEqual(got, []int{7, 8, 9, 0, 1}) {
+		t.Fatal("unexpected value", got)
+	}
+	if s := RangeUntil(2); ConsAll(s) != s {
+		t.Fatal("expected sequence to be unchanged")
+	}
+}
+
 func TestString(t *testing.T) {
 	t.Run("Range", func(t *testing.T) {
 		s := RangeUntil(10)
@@ -849,6 +2357,13 @@ func (i IntSeq) Next() Sequence {
 	}
 	return i[1:]
 }
+
+func (i IntSeq) seq() Sequence {
+	if len(i) == 0 {
+		return nil
+	}
+	return i
+}
 func BenchmarkMap(b *testing.B) {
 	b.Run("native-loop", func(b *testing.B) {
 		s := make([]int, b.N)
@@ -0,0 +1,68 @@
+package seq
+
+import (
+	"math/rand"
+	"reflect"
+)
+
+// Shuffle returns a sequence of the elements of coll in a random order.
+// Shuffle is not lazy, coll is fully realized before any element is
+// returned, so it must not be used on infinite sequences. coll is any
+// type that can be converted to a Sequence by Seq.
+func Shuffle(coll interface{}) Sequence {
+	return shuffle(rand.Intn, coll)
+}
+
+// ShuffleSeeded is Shuffle using rng as the source of randomness, which
+// makes the permutation reproducible for a given seed.
+func ShuffleSeeded(rng *rand.Rand, coll interface{}) Sequence {
+	return shuffle(rng.Intn, coll)
+}
+
+func shuffle(intn func(int) int, coll interface{}) Sequence {
+	out := Slice(coll)
+	// Fisher-Yates
+	for i := len(out) - 1; i > 0; i-- {
+		j := intn(i + 1)
+		out[i], out[j] = out[j], out[i]
+	}
+	return sliceSequence(reflect.ValueOf(out))
+}
+
+// Sample returns a sequence of n elements chosen at random, without
+// replacement, from coll. The elements are chosen with reservoir
+// sampling in a single pass that holds at most n elements, so large
+// sequences may be sampled without loading them entirely, but coll
+// must be finite. The chosen elements are in no particular order. If
+// coll has n or fewer elements all of them are returned in their
+// original order. coll is any type that can be converted to a Sequence
+// by Seq.
+func Sample(n int, coll interface{}) Sequence {
+	return reservoirSample(rand.Intn, n, coll)
+}
+
+// SampleSeeded is Sample using rng as the source of randomness, which
+// makes the selection reproducible for a given seed.
+func SampleSeeded(rng *rand.Rand, n int, coll interface{}) Sequence {
+	return reservoirSample(rng.Intn, n, coll)
+}
+
+func reservoirSample(intn func(int) int, n int, coll interface{}) Sequence {
+	if n <= 0 {
+		return nil
+	}
+	reservoir := make([]interface{}, 0, n)
+	i := 0
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		switch {
+		case i < n:
+			reservoir = append(reservoir, First(s))
+		default:
+			if j := intn(i + 1); j < n {
+				reservoir[j] = First(s)
+			}
+		}
+		i++
+	}
+	return sliceSequence(reflect.ValueOf(reservoir))
+}
@@ -0,0 +1,69 @@
+package seq
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestShuffleSeeded(t *testing.T) {
+	shuffled := func() []int {
+		rng := rand.New(rand.NewSource(7))
+		return Into([]int{}, ShuffleSeeded(rng, RangeUntil(20))).([]int)
+	}
+	first, second := shuffled(), shuffled()
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("permutation not reproducible", first, second)
+	}
+	ordered := Into([]int{}, RangeUntil(20)).([]int)
+	if reflect.DeepEqual(first, ordered) {
+		t.Fatal("expected elements to be permuted")
+	}
+	sort.Ints(first)
+	if !reflect.DeepEqual(first, ordered) {
+		t.Fatal("elements not preserved", first)
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	got := Into([]string{}, Shuffle([]string{"a", "b", "a", "c"})).([]string)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, []string{"a", "a", "b", "c"}) {
+		t.Fatal("elements not preserved", got)
+	}
+	if Seq(Shuffle(nil)) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func TestSampleSeeded(t *testing.T) {
+	sampled := func() []int {
+		rng := rand.New(rand.NewSource(3))
+		return Into([]int{}, SampleSeeded(rng, 5, RangeUntil(1000))).([]int)
+	}
+	first, second := sampled(), sampled()
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("selection not reproducible", first, second)
+	}
+	if len(first) != 5 {
+		t.Fatal("unexpected sample size", len(first))
+	}
+	seen := make(map[int]bool)
+	for _, v := range first {
+		if v < 0 || v >= 1000 || seen[v] {
+			t.Fatal("unexpected sample", first)
+		}
+		seen[v] = true
+	}
+}
+
+func TestSampleShort(t *testing.T) {
+	got := Into([]int{}, Sample(10, RangeUntil(4)))
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Fatal("expected whole sequence in order got", got)
+	}
+	if Seq(Sample(0, RangeUntil(4))) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
@@ -0,0 +1,55 @@
+package seq
+
+import (
+	"cmp"
+	"slices"
+)
+
+type sortedMapSeq[K cmp.Ordered, V any] struct {
+	keys []K
+	m    map[K]V
+}
+
+func (s sortedMapSeq[K, V]) First() interface{} {
+	k := s.keys[0]
+	return mapEntry{
+		key: k,
+		val: s.m[k],
+	}
+}
+
+func (s sortedMapSeq[K, V]) Next() Sequence {
+	if len(s.keys) == 1 {
+		return nil
+	}
+	return sortedMapSeq[K, V]{
+		keys: s.keys[1:],
+		m:    s.m,
+	}
+}
+
+func (s sortedMapSeq[K, V]) String() string {
+	return seqString(s)
+}
+
+func (s sortedMapSeq[K, V]) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
+// SortedMapSeqG returns a sequence of the MapEntry values of m in
+// ascending key order. The keys are ordered using the Ordered
+// constraint so no comparator is required.
+func SortedMapSeqG[K cmp.Ordered, V any](m map[K]V) Sequence {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return sortedMapSeq[K, V]{
+		keys: keys,
+		m:    m,
+	}
+}
@@ -0,0 +1,98 @@
+package seq
+
+import (
+	"sync"
+)
+
+type stableSeq struct {
+	mu            sync.Mutex
+	src           Sequence
+	first         interface{}
+	firstRealized bool
+	next          Sequence
+	nextRealized  bool
+}
+
+// Stable wraps the passed in sequence so that the First and Next of
+// each element are computed at most once and then cached. The result
+// may safely be traversed many times and from multiple goroutines
+// regardless of how the underlying sequence is implemented. coll is
+// any type that can be converted to a Sequence by Seq.
+func Stable(coll interface{}) Sequence {
+	s := Seq(coll)
+	switch s := s.(type) {
+	case nil:
+		return nil
+	case *stableSeq:
+		return s
+	default:
+		return &stableSeq{src: s}
+	}
+}
+
+func (s *stableSeq) First() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.firstRealized {
+		s.first = s.src.First()
+		s.firstRealized = true
+		s.release()
+	}
+	return s.first
+}
+
+func (s *stableSeq) Next() Sequence {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.nextRealized {
+		if next := Seq(s.src.Next()); next != nil {
+			s.next = &stableSeq{src: next}
+		}
+		s.nextRealized = true
+		s.release()
+	}
+	return s.next
+}
+
+// release drops the underlying sequence once it is no longer needed.
+func (s *stableSeq) release() {
+	if s.firstRealized && s.nextRealized {
+		s.src = nil
+	}
+}
+
+func (s *stableSeq) String() string {
+	return seqString(s)
+}
+
+func (s *stableSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
+type reIterable struct {
+	once sync.Once
+	coll interface{}
+	seq  Sequence
+}
+
+// ReIterable returns a Seqable whose Seq method returns a traversal of
+// the elements of coll from the beginning every time it is called. The
+// elements are realized from coll at most once, on first use, and are
+// cached as by Stable, so single pass sources such as channels can be
+// traversed any number of times. coll is any type that can be converted
+// to a Sequence by Seq.
+func ReIterable(coll interface{}) Seqable {
+	return &reIterable{coll: coll}
+}
+
+func (r *reIterable) Seq() Sequence {
+	r.once.Do(func() {
+		r.seq = Stable(r.coll)
+		r.coll = nil
+	})
+	return r.seq
+}
+
+func (r *reIterable) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(r.Seq())
+}
@@ -0,0 +1,86 @@
+package seq
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// recomputingSeq builds a fresh cell on every call to Next and counts
+// how many times that happens.
+type recomputingSeq struct {
+	n, end int
+	calls  *int
+}
+
+func (s recomputingSeq) First() interface{} {
+	return s.n * s.n
+}
+
+func (s recomputingSeq) Next() Sequence {
+	*s.calls++
+	if s.n+1 >= s.end {
+		return nil
+	}
+	return recomputingSeq{n: s.n + 1, end: s.end, calls: s.calls}
+}
+
+func TestStable(t *testing.T) {
+	calls := 0
+	seq := Stable(recomputingSeq{end: 5, calls: &calls})
+	first, second := fmt.Sprint(seq), fmt.Sprint(seq)
+	if first != "(0 1 4 9 16)" || first != second {
+		t.Fatal("unexpected value", first, second)
+	}
+	if calls != 5 {
+		t.Fatal("Next called", calls, "times, expected 5")
+	}
+	if Stable(seq) != seq {
+		t.Fatal("expected stable sequence to be returned as is")
+	}
+	if Stable(nil) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func TestStableConcurrent(t *testing.T) {
+	calls := 0
+	seq := Stable(recomputingSeq{end: 100, calls: &calls})
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = fmt.Sprint(seq)
+		}(i)
+	}
+	wg.Wait()
+	for _, r := range results[1:] {
+		if r != results[0] {
+			t.Fatal("traversals differ", r, results[0])
+		}
+	}
+	if calls != 100 {
+		t.Fatal("Next called", calls, "times, expected 100")
+	}
+}
+
+func TestReIterable(t *testing.T) {
+	calls := 0
+	r := ReIterable(Repeatedly(5, func() int {
+		calls++
+		return calls
+	}))
+	if calls != 0 {
+		t.Fatal("source realized before use")
+	}
+	for i := 0; i < 3; i++ {
+		if got := fmt.Sprint(Seq(r)); got != "(1 2 3 4 5)" {
+			t.Fatal("unexpected value on traversal", i, got)
+		}
+	}
+	if calls != 5 {
+		t.Fatal("source realized more than once", calls)
+	}
+}
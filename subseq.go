@@ -0,0 +1,30 @@
+package seq
+
+// Subseq returns a lazy sequence of the elements x of coll for which
+// lo <= x <= hi, that is !lessThan(x, lo) && !lessThan(hi, x). coll
+// must already be sorted in ascending order according to lessThan:
+// elements are dropped while they are below lo and the sequence ends
+// at the first element above hi, so elements of an unsorted coll may be
+// missed. lessThan must match the signature func(a, b T) bool and will
+// be called using reflection unless it is the non-specialized type
+// func(a, b interface{}) bool. coll is any type that can be converted
+// to a Sequence by Seq.
+func Subseq(coll interface{}, lessThan interface{}, lo, hi interface{}) Sequence {
+	less := wrapLess(lessThan)
+	return TakeWhile(func(x interface{}) bool {
+		return !less(hi, x)
+	}, DropWhile(func(x interface{}) bool {
+		return less(x, lo)
+	}, coll))
+}
+
+func wrapLess(f interface{}) func(a, b interface{}) bool {
+	switch fn := f.(type) {
+	case func(a, b interface{}) bool:
+		return fn
+	default:
+		return func(a, b interface{}) bool {
+			return apply(f, a, b).(bool)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package seq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubseq(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	got := Into([]int{}, Subseq(RangeUntil(20), less, 5, 10))
+	exp := []int{5, 6, 7, 8, 9, 10}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatal("wanted", exp, "got", got)
+	}
+	if Seq(Subseq(RangeUntil(20), less, 30, 40)) != nil {
+		t.Fatal("expected empty window above the elements")
+	}
+	if Seq(Subseq(RangeUntil(20), less, 10, 5)) != nil {
+		t.Fatal("expected empty window for lo > hi")
+	}
+	words := Subseq([]string{"apple", "banana", "cherry", "date"},
+		func(a, b interface{}) bool { return a.(string) < b.(string) },
+		"b", "d")
+	if got := Into([]string{}, words); !reflect.DeepEqual(got,
+		[]string{"banana", "cherry"}) {
+		t.Fatal("unexpected value", got)
+	}
+}
+
+func TestSubseqInfinite(t *testing.T) {
+	got := Into([]int{}, Subseq(Iterate(func(x int) int {
+		return x + 3
+	}, 0), func(a, b int) bool { return a < b }, 10, 20))
+	if !reflect.DeepEqual(got, []int{12, 15, 18}) {
+		t.Fatal("unexpected value", got)
+	}
+}
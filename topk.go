@@ -0,0 +1,65 @@
+package seq
+
+import (
+	"container/heap"
+)
+
+// TopK returns a sequence of the k largest elements of coll, as ordered
+// by less, in descending order. coll is scanned once while keeping at
+// most k elements in a min-heap so memory use is O(k) regardless of the
+// length of coll, which must be finite. If coll has fewer than k
+// elements all of them are returned. coll is any type that can be
+// converted to a Sequence by Seq.
+func TopK(k int, less func(a, b interface{}) bool, coll interface{}) Sequence {
+	if k <= 0 {
+		return nil
+	}
+	h := &boundedHeap{k: k, less: less}
+	for s := Seq(coll); s != nil; s = Seq(Next(s)) {
+		h.offer(First(s))
+	}
+	out := make([]interface{}, h.Len())
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = heap.Pop(h)
+	}
+	return Seq(out)
+}
+
+// boundedHeap is a min-heap holding at most k elements.
+type boundedHeap struct {
+	k     int
+	less  func(a, b interface{}) bool
+	items []interface{}
+}
+
+func (h *boundedHeap) offer(x interface{}) {
+	switch {
+	case len(h.items) < h.k:
+		heap.Push(h, x)
+	case h.less(h.items[0], x):
+		h.items[0] = x
+		heap.Fix(h, 0)
+	}
+}
+
+func (h *boundedHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *boundedHeap) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+func (h *boundedHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *boundedHeap) Push(x interface{}) {
+	h.items = append(h.items, x)
+}
+
+func (h *boundedHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
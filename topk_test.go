@@ -0,0 +1,48 @@
+package seq
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func intLess(a, b interface{}) bool {
+	return a.(int) < b.(int)
+}
+
+func TestTopK(t *testing.T) {
+	const n = 100000
+	perm := rand.New(rand.NewSource(1)).Perm(n)
+	shuffled := Map(func(i int) int { return perm[i] }, RangeUntil(n))
+	got := fmt.Sprint(TopK(3, intLess, shuffled))
+	exp := fmt.Sprint(Seq([]int{n - 1, n - 2, n - 3}))
+	if got != exp {
+		t.Fatal("wanted", exp, "got", got)
+	}
+}
+
+func TestTopKBounded(t *testing.T) {
+	h := &boundedHeap{k: 3, less: intLess}
+	for _, v := range rand.Perm(1000) {
+		h.offer(v)
+		if h.Len() > 3 {
+			t.Fatal("heap grew to", h.Len())
+		}
+	}
+}
+
+func TestTopKShort(t *testing.T) {
+	if got := fmt.Sprint(TopK(5, intLess, []int{2, 9, 4})); got != "(9 4 2)" {
+		t.Fatal("unexpected value", got)
+	}
+	if TopK(0, intLess, []int{1}) != nil || TopK(3, intLess, nil) != nil {
+		t.Fatal("expected empty sequence")
+	}
+}
+
+func ExampleTopK() {
+	fmt.Println(TopK(3, func(a, b interface{}) bool {
+		return a.(int) < b.(int)
+	}, []int{5, 1, 9, 3, 7, 2}))
+	// Output: (9 7 5)
+}
@@ -0,0 +1,44 @@
+package seq
+
+// TreeSeq returns a lazy sequence of the nodes in a tree in depth-first
+// pre-order. isBranch must match the signature func(node nT) bool and
+// reports whether a node can have children. children must match the
+// signature func(node nT) cT where cT is any type that can be
+// converted to a Sequence by Seq; it is only called on branch nodes.
+// Both are called with reflection unless they are the non-specialized
+// types func(interface{}) bool and func(interface{}) interface{}.
+func TreeSeq(isBranch interface{}, children interface{}, root interface{}) Sequence {
+	t := &treeWalker{
+		isBranch: wrapPred(isBranch),
+		children: wrapMapper(children),
+	}
+	return t.walk(root)
+}
+
+type treeWalker struct {
+	isBranch func(interface{}) bool
+	children func(interface{}) interface{}
+}
+
+func (t *treeWalker) walk(node interface{}) Sequence {
+	return LazySeq(func() Sequence {
+		var kids Sequence
+		if t.isBranch(node) {
+			kids = LazySeq(func() Sequence {
+				return t.walkAll(t.children(node))
+			})
+		}
+		return Cons(node, kids)
+	})
+}
+
+func (t *treeWalker) walkAll(nodes interface{}) Sequence {
+	s := Seq(nodes)
+	if s == nil {
+		return nil
+	}
+	return LazyCat(
+		func() Sequence { return t.walk(First(s)) },
+		func() Sequence { return t.walkAll(Next(s)) },
+	)
+}
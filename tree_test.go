@@ -0,0 +1,72 @@
+package seq
+
+import (
+	"fmt"
+	"testing"
+)
+
+type treeNode struct {
+	name     string
+	children []*treeNode
+}
+
+func TestTreeSeq(t *testing.T) {
+	root := &treeNode{name: "a", children: []*treeNode{
+		{name: "b", children: []*treeNode{
+			{name: "c"},
+			{name: "d"},
+		}},
+		{name: "e"},
+		{name: "f", children: []*treeNode{
+			{name: "g", children: []*treeNode{
+				{name: "h"},
+			}},
+		}},
+	}}
+	isBranch := func(n *treeNode) bool {
+		return len(n.children) > 0
+	}
+	children := func(n *treeNode) []*treeNode {
+		return n.children
+	}
+	expected := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	seq := TreeSeq(isBranch, children, root)
+	for idx, name := range expected {
+		n := First(seq).(*treeNode)
+		if n.name != name {
+			t.Fatal("wanted", name, "got", n.name, "at", idx)
+		}
+		seq = Next(seq)
+	}
+	if Seq(seq) != nil {
+		t.Fatal("unexpected value", seq)
+	}
+}
+
+func TestTreeSeqIsLazy(t *testing.T) {
+	calls := 0
+	isBranch := func(n int) bool {
+		return true
+	}
+	children := func(n int) Sequence {
+		calls++
+		return Seq([]int{n * 2, n*2 + 1})
+	}
+	DoRun(Take(5, TreeSeq(isBranch, children, 1)))
+	if calls != 4 {
+		t.Fatal("children called", calls, "times, expected 4")
+	}
+}
+
+func ExampleTreeSeq() {
+	tree := []interface{}{1, []interface{}{2, []interface{}{3}}, 4}
+	isBranch := func(x interface{}) bool {
+		_, ok := x.([]interface{})
+		return ok
+	}
+	children := func(x []interface{}) []interface{} {
+		return x
+	}
+	fmt.Println(Remove(isBranch, TreeSeq(isBranch, children, tree)))
+	// Output: (1 2 3 4)
+}
@@ -39,12 +39,35 @@ func XfrmSequence(xf transduce.Transducer, coll Sequence) Sequence {
 	return ret
 }
 
+// XfrmPerPartition returns a lazy sequence that is the concatenation of
+// stepping a fresh instance of the transducer over each partition of
+// the passed in sequence. Partitions are runs of consecutive elements
+// for which partitionFn returns the same value, as with PartitionBy.
+// This keeps the state of stateful transducers, such as Take or Dedupe,
+// from leaking across partitions. partitionFn must match the signature
+// func(i iT) oT. coll is any type that can be converted to a Sequence
+// by Seq.
+func XfrmPerPartition(
+	partitionFn interface{},
+	xf transduce.Transducer,
+	coll interface{},
+) Sequence {
+	return catSeqs(Map(func(part interface{}) interface{} {
+		return XfrmSequence(xf, Seq(part))
+	}, PartitionBy(partitionFn, coll)))
+}
+
 func (s *xfrmSeq) Seq() Sequence {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if s.bufferedColl != nil || s.completed {
+	// A node is only ever realized once, afterwards the cached state
+	// is returned without touching the shared step.
+	if s.bufferedColl != nil {
 		return s
 	}
+	if s.completed {
+		return nil
+	}
 	/*
 	   This process should cache the state for this element in the
 	   sequence and then always return that cached state
@@ -62,10 +85,24 @@ func (s *xfrmSeq) Seq() Sequence {
 	*/
 	coll := s.coll
 	for s.bufferedColl == nil {
-		res := s.step.Step(nil, First(coll))
-		coll = Next(coll)
+		// coll may be a lazy sequence that turns out to be empty.
+		coll = Seq(coll)
+		var reduced bool
+		if coll != nil {
+			res := s.step.Step(nil, First(coll))
+			coll = Next(coll)
+			reduced = transduce.IsReduced(res)
+		}
+		if coll == nil || reduced {
+			// The source is exhausted or the transducer is done,
+			// completing may flush values held by a stateful
+			// transducer so it must happen before the buffer is
+			// handed off.
+			s.step.Result(nil)
+			s.completed = true
+		}
 		if s.buffer.head != nil {
-			if coll != nil && !transduce.IsReduced(res) {
+			if !s.completed {
 				s.buffer.tail.next = &xfrmSeq{
 					step:   s.step,
 					coll:   coll,
@@ -76,23 +113,16 @@ func (s *xfrmSeq) Seq() Sequence {
 			s.buffer.clear()
 			s.buffer = nil
 		}
-		if transduce.IsReduced(res) {
-			s.step.Result(nil)
-			s.completed = true
-			break
-		}
-		if coll == nil {
-			s.step.Result(nil)
-			if s.buffer != nil && s.buffer.head != nil {
-				s.bufferedColl = s.buffer.head
-				s.buffer.clear()
-				s.buffer = nil
-			}
-			s.completed = true
+		if s.completed {
 			break
 		}
-
 	}
+	// The step and source now belong to the node following the
+	// buffered values, if any. Dropping them prevents this node from
+	// stepping the transducer again.
+	s.step = nil
+	s.coll = nil
+	s.buffer = nil
 	if s.completed && s.bufferedColl == nil {
 		return nil
 	}
@@ -113,6 +143,10 @@ func (s *xfrmSeq) String() string {
 	return seqString(s)
 }
 
+func (s *xfrmSeq) MarshalJSON() ([]byte, error) {
+	return marshalSeqJSON(s)
+}
+
 type buffer struct {
 	head   *cons
 	tail   *cons